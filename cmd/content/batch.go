@@ -0,0 +1,330 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/agentplexus/agent-team-content/internal/agent"
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/spf13/cobra"
+)
+
+var (
+	batchInput      string
+	batchNameTmpl   string
+	batchParallel   int
+	batchConcurrent int
+	batchForce      bool
+)
+
+// Batch file statuses recorded in the report.
+const (
+	batchStatusOK      = "ok"
+	batchStatusFailed  = "failed"
+	batchStatusSkipped = "skipped"
+)
+
+func newBatchCmd() *cobra.Command {
+	batchCmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Generate content for every conversation in a directory or glob",
+		RunE:  runBatch,
+	}
+
+	batchCmd.Flags().StringVarP(&batchInput, "input", "i", "", "Directory or glob of conversation files (JSON or Markdown)")
+	batchCmd.Flags().StringVarP(&outputDir, "output", "o", "./output", "Output root directory")
+	batchCmd.Flags().StringVar(&batchNameTmpl, "name-template", "{{.Name}}", "Output subdirectory template (fields: Name, Ext, Dir, Title, Slug, Index)")
+	batchCmd.Flags().IntVar(&batchParallel, "parallel", 2, "Number of conversations processed at once")
+	batchCmd.Flags().IntVar(&batchConcurrent, "concurrency", 4, "Maximum agent requests in flight across all conversations")
+	batchCmd.Flags().BoolVar(&batchForce, "force", false, "Regenerate conversations that were already generated successfully")
+	batchCmd.Flags().StringVar(&agentList, "agents", "", "Comma-separated list of agents (default: all)")
+	addAgentFlags(batchCmd)
+	if err := batchCmd.MarkFlagRequired("input"); err != nil {
+		panic(err)
+	}
+
+	return batchCmd
+}
+
+// BatchReport aggregates the outcome of a batch run.
+type BatchReport struct {
+	Input       string            `json:"input"`
+	GeneratedAt string            `json:"generated_at"`
+	Duration    string            `json:"duration"`
+	Succeeded   int               `json:"succeeded"`
+	Failed      int               `json:"failed"`
+	Skipped     int               `json:"skipped"`
+	Files       []BatchFileReport `json:"files"`
+}
+
+// BatchFileReport describes the outcome for a single conversation.
+type BatchFileReport struct {
+	InputFile string   `json:"input_file"`
+	OutputDir string   `json:"output_dir"`
+	Status    string   `json:"status"`
+	Duration  string   `json:"duration,omitempty"`
+	Outputs   int      `json:"outputs"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// batchName holds the fields available to --name-template.
+type batchName struct {
+	Name  string // Input file name without extension
+	Ext   string // Input file extension without the dot
+	Dir   string // Base name of the input file's directory
+	Title string // Conversation title, if any
+	Slug  string // Slugified title, falling back to Name
+	Index int    // 1-based position of the file in the batch
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	files, err := resolveBatchInputs(batchInput)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no conversation files found in %s", batchInput)
+	}
+
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(batchNameTmpl)
+	if err != nil {
+		return fmt.Errorf("invalid name template: %w", err)
+	}
+
+	opts := agentOpts
+	opts.MaxConcurrent = batchConcurrent
+
+	orchestrator, err := newOrchestrator(agentList, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	fmt.Printf("Processing %d conversation(s) from: %s\n", len(files), batchInput)
+	fmt.Printf("Output directory: %s\n", outputDir)
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	parallel := batchParallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	startTime := time.Now()
+	reports := make([]BatchFileReport, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				reports[i] = processBatchFile(ctx, orchestrator, tmpl, files[i], i+1)
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := BatchReport{
+		Input:       batchInput,
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Duration:    time.Since(startTime).String(),
+		Files:       reports,
+	}
+	for _, r := range reports {
+		switch r.Status {
+		case batchStatusOK:
+			report.Succeeded++
+		case batchStatusFailed:
+			report.Failed++
+		case batchStatusSkipped:
+			report.Skipped++
+		}
+	}
+
+	reportPath := filepath.Join(outputDir, "batch-report.json")
+	reportData, _ := json.MarshalIndent(report, "", "  ")
+	if err := os.WriteFile(reportPath, reportData, 0600); err != nil {
+		fmt.Printf("[WARN] Failed to write batch-report.json: %v\n", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("Batch completed in %s: %d succeeded, %d failed, %d skipped\n",
+		time.Since(startTime).Round(time.Millisecond), report.Succeeded, report.Failed, report.Skipped)
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d conversation(s) failed", report.Failed)
+	}
+
+	return nil
+}
+
+// batchComplete reports whether a previous run into dir produced output for
+// every one of the agents without errors, so the conversation can be skipped.
+func batchComplete(dir string, agents []string) bool {
+	summary, err := loadSummary(dir)
+	if err != nil || len(summary.Errors) > 0 {
+		return false
+	}
+	generated := make(map[string]bool)
+	for _, out := range summary.Outputs {
+		generated[out.Agent] = true
+	}
+	for _, name := range agents {
+		if !generated[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// processBatchFile generates content for one conversation into its own
+// subdirectory of the output root.
+func processBatchFile(ctx context.Context, orchestrator *agent.Orchestrator, tmpl *template.Template, input string, index int) BatchFileReport {
+	report := BatchFileReport{InputFile: input, Status: batchStatusFailed}
+	prefix := fmt.Sprintf("[%s] ", filepath.Base(input))
+
+	conv, err := conversation.ParseFile(input)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to parse conversation: %v", err))
+		fmt.Printf("%s[ERROR] failed to parse conversation: %v\n", prefix, err)
+		return report
+	}
+
+	dir, err := batchOutputDir(tmpl, input, conv, index)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		fmt.Printf("%s[ERROR] %v\n", prefix, err)
+		return report
+	}
+	report.OutputDir = dir
+
+	if !batchForce && batchComplete(dir, orchestrator.Agents()) {
+		report.Status = batchStatusSkipped
+		fmt.Printf("%s[SKIP] already generated in %s\n", prefix, dir)
+		return report
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to create output directory: %v", err))
+		fmt.Printf("%s[ERROR] failed to create output directory: %v\n", prefix, err)
+		return report
+	}
+
+	startTime := time.Now()
+	results := orchestrator.Generate(ctx, conv)
+	duration := time.Since(startTime)
+
+	summary, successCount, errorCount := writeResults(dir, input, results, duration, prefix)
+	if err := writeSummary(dir, summary); err != nil {
+		fmt.Printf("%s[WARN] Failed to write summary.json: %v\n", prefix, err)
+	}
+
+	report.Duration = duration.String()
+	report.Outputs = successCount
+	for _, result := range results {
+		if result.Error != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", result.AgentName, result.Error))
+		}
+	}
+	if errorCount == 0 {
+		report.Status = batchStatusOK
+	}
+
+	return report
+}
+
+// resolveBatchInputs expands a directory or glob into a sorted list of
+// conversation files.
+func resolveBatchInputs(input string) ([]string, error) {
+	var matches []string
+
+	if info, err := os.Stat(input); err == nil && info.IsDir() {
+		entries, err := os.ReadDir(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input directory: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				matches = append(matches, filepath.Join(input, entry.Name()))
+			}
+		}
+	} else {
+		matches, err = filepath.Glob(input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input pattern: %w", err)
+		}
+	}
+
+	var files []string
+	for _, path := range matches {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".md":
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// batchOutputDir renders the name template for a conversation and returns
+// the resulting directory under the output root.
+func batchOutputDir(tmpl *template.Template, input string, conv *conversation.Conversation, index int) (string, error) {
+	ext := filepath.Ext(input)
+	name := strings.TrimSuffix(filepath.Base(input), ext)
+
+	slug := slugify(conv.Title)
+	if slug == "" {
+		slug = slugify(name)
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, batchName{
+		Name:  name,
+		Ext:   strings.TrimPrefix(ext, "."),
+		Dir:   filepath.Base(filepath.Dir(input)),
+		Title: conv.Title,
+		Slug:  slug,
+		Index: index,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render name template: %w", err)
+	}
+
+	rel := filepath.Clean(strings.TrimSpace(buf.String()))
+	if rel == "." || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("name template produced invalid directory %q", buf.String())
+	}
+
+	return filepath.Join(outputDir, rel), nil
+}
+
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify lowercases s and replaces runs of non-alphanumerics with hyphens.
+func slugify(s string) string {
+	return strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
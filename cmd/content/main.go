@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,15 +14,18 @@ import (
 	"github.com/agentplexus/agent-team-content/internal/agent"
 	"github.com/agentplexus/agent-team-content/internal/conversation"
 	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/render"
 	"github.com/spf13/cobra"
 )
 
 var (
 	inputFile string
 	outputDir string
-	marpTheme string
 	agentList string
 	model     string
+
+	onlyFailed  bool
+	forceAgents string
 )
 
 var version = "0.1.0"
@@ -46,9 +51,13 @@ func main() {
 
 	generateCmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input conversation file (JSON or Markdown)")
 	generateCmd.Flags().StringVarP(&outputDir, "output", "o", "./output", "Output directory")
-	generateCmd.Flags().StringVar(&marpTheme, "theme", "", "Custom Marp theme CSS file")
 	generateCmd.Flags().StringVar(&agentList, "agents", "", "Comma-separated list of agents (default: all)")
-	generateCmd.Flags().StringVar(&model, "model", "claude-sonnet-4-20250514", "Claude model to use")
+	addAgentFlags(generateCmd)
+	generateCmd.Flags().BoolVar(&onlyFailed, "only-failed", false, "Rerun only agents that failed or are missing in the previous summary.json")
+	generateCmd.Flags().StringVar(&forceAgents, "force", "", "Comma-separated agents to regenerate, keeping all other outputs")
+	generateCmd.Flags().StringVar(&renderOnGenerate, "render", "", "Also render presentation outputs: html, pptx, or both comma-separated (--render alone means html)")
+	generateCmd.Flags().Lookup("render").NoOptDefVal = render.TargetHTML
+	generateCmd.Flags().StringVar(&renderOpts.PPTXTemplate, "pptx-template", "", "PowerPoint file whose theme colors and fonts are used with --render=pptx")
 	if err := generateCmd.MarkFlagRequired("input"); err != nil {
 		panic(err)
 	}
@@ -72,7 +81,7 @@ func main() {
 		},
 	}
 
-	rootCmd.AddCommand(generateCmd, listCmd, versionCmd)
+	rootCmd.AddCommand(generateCmd, newBatchCmd(), newWatchCmd(), newServeCmd(), newMCPCmd(), newRenderCmd(), newThemesCmd(), listCmd, versionCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -80,38 +89,40 @@ func main() {
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
+	if renderOnGenerate != "" {
+		if err := render.CheckTargets(splitList(renderOnGenerate)); err != nil {
+			return err
+		}
+	}
+
 	// Parse conversation
 	conv, err := conversation.ParseFile(inputFile)
 	if err != nil {
 		return fmt.Errorf("failed to parse conversation: %w", err)
 	}
 
-	// Create LLM client
-	cfg := llm.DefaultConfig()
-	cfg.Model = model
+	// Work out which agents to run
+	agentNames := agentList
+	incremental := onlyFailed || forceAgents != ""
+	var previous Summary
+	if incremental {
+		previous, err = loadSummary(outputDir)
+		if err != nil && (onlyFailed || !errors.Is(err, fs.ErrNotExist)) {
+			return fmt.Errorf("failed to load previous summary: %w", err)
+		}
 
-	client, err := llm.NewClient(cfg)
-	if err != nil {
-		return fmt.Errorf("failed to create LLM client: %w", err)
+		rerun := rerunAgents(previous)
+		if len(rerun) == 0 {
+			fmt.Println("Nothing to regenerate")
+			return nil
+		}
+		agentNames = strings.Join(rerun, ",")
 	}
 
 	// Create orchestrator
-	opts := agent.Options{
-		MarpTheme: marpTheme,
-	}
-
-	var orchestrator *agent.Orchestrator
-	if agentList != "" {
-		agents := strings.Split(agentList, ",")
-		for i := range agents {
-			agents[i] = strings.TrimSpace(agents[i])
-		}
-		orchestrator, err = agent.NewOrchestratorWithAgents(client, agents, opts)
-		if err != nil {
-			return fmt.Errorf("failed to create orchestrator: %w", err)
-		}
-	} else {
-		orchestrator = agent.NewOrchestrator(client, opts)
+	orchestrator, err := newOrchestrator(agentNames, agentOpts)
+	if err != nil {
+		return err
 	}
 
 	// Create output directory
@@ -122,6 +133,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	// Generate content
 	fmt.Printf("Generating content from: %s\n", inputFile)
 	fmt.Printf("Output directory: %s\n", outputDir)
+	if incremental {
+		fmt.Printf("Regenerating: %s\n", strings.ReplaceAll(agentNames, ",", ", "))
+	}
 	fmt.Println()
 
 	ctx := context.Background()
@@ -129,10 +143,133 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	results := orchestrator.Generate(ctx, conv)
 	duration := time.Since(startTime)
 
+	if renderOnGenerate != "" {
+		renderOpts.MarpTheme = agentOpts.MarpTheme
+		renderOpts.RevealTheme = agentOpts.RevealTheme
+		renderOpts.ThemesDir = agentOpts.ThemesDir
+		results = renderResults(results, splitList(renderOnGenerate), renderOpts)
+	}
+
 	// Write results
+	summary, successCount, errorCount := writeResults(outputDir, inputFile, results, duration, "  ")
+	if incremental {
+		summary = mergeSummary(previous, summary)
+	}
+
+	// Write summary
+	if err := writeSummary(outputDir, summary); err != nil {
+		fmt.Printf("  [WARN] Failed to write summary.json: %v\n", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("Completed in %s: %d successful, %d errors\n", duration.Round(time.Millisecond), successCount, errorCount)
+
+	if errorCount > 0 {
+		return fmt.Errorf("%d agent(s) failed", errorCount)
+	}
+
+	return nil
+}
+
+// rerunAgents returns the agents an incremental run should regenerate: with
+// --only-failed, the selected agents that errored, are missing from the
+// previous summary, or whose output file no longer exists; plus every agent
+// named in --force.
+func rerunAgents(previous Summary) []string {
+	rerun := make(map[string]bool)
+
+	if onlyFailed {
+		selected := agent.ListAgents()
+		if agentList != "" {
+			selected = splitList(agentList)
+		}
+
+		succeeded := make(map[string]bool)
+		for _, out := range previous.Outputs {
+			if _, err := os.Stat(filepath.Join(outputDir, out.File)); err == nil {
+				succeeded[out.Agent] = true
+			}
+		}
+		for _, failure := range previous.Errors {
+			succeeded[failure.Agent] = false
+		}
+
+		for _, name := range selected {
+			if !succeeded[name] {
+				rerun[name] = true
+			}
+		}
+	}
+
+	if forceAgents != "" {
+		for _, name := range splitList(forceAgents) {
+			rerun[name] = true
+		}
+	}
+
+	// Keep ListAgents order for stable output, then any unknown names so the
+	// orchestrator reports them.
+	var names []string
+	for _, name := range agent.ListAgents() {
+		if rerun[name] {
+			names = append(names, name)
+			delete(rerun, name)
+		}
+	}
+	for name := range rerun {
+		names = append(names, name)
+	}
+	return names
+}
+
+// newOrchestrator creates the LLM client and an orchestrator for the
+// comma-separated agent list, or for all agents when the list is empty.
+func newOrchestrator(agentNames string, opts agent.Options) (*agent.Orchestrator, error) {
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var orchestrator *agent.Orchestrator
+	if agentNames == "" {
+		orchestrator, err = agent.NewOrchestrator(client, opts)
+	} else {
+		orchestrator, err = agent.NewOrchestratorWithAgents(client, splitList(agentNames), opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	return orchestrator, nil
+}
+
+// newClient creates the Claude client for the --model flag.
+func newClient() (*llm.Client, error) {
+	cfg := llm.DefaultConfig()
+	cfg.Model = model
+
+	client, err := llm.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	return client, nil
+}
+
+// splitList splits a comma-separated flag value, trimming whitespace.
+func splitList(value string) []string {
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+// writeResults writes each successful result into outputDir, printing one
+// status line per agent with the given prefix, and returns the run summary
+// along with success and error counts.
+func writeResults(outputDir, input string, results []agent.Result, duration time.Duration, prefix string) (Summary, int, int) {
 	var successCount, errorCount int
 	summary := Summary{
-		InputFile:   inputFile,
+		InputFile:   input,
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 		Duration:    duration.String(),
 		Outputs:     make([]OutputSummary, 0, len(results)),
@@ -140,42 +277,116 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	for _, result := range results {
 		if result.Error != nil {
-			fmt.Printf("  [ERROR] %s: %v\n", result.AgentName, result.Error)
+			fmt.Printf("%s[ERROR] %s: %v\n", prefix, result.AgentName, result.Error)
 			errorCount++
+			summary.Errors = append(summary.Errors, ErrorSummary{
+				Agent: result.AgentName,
+				Error: result.Error.Error(),
+			})
 			continue
 		}
 
 		outputPath := filepath.Join(outputDir, result.OutputFile)
 		if err := os.WriteFile(outputPath, []byte(result.Content), 0600); err != nil {
-			fmt.Printf("  [ERROR] Failed to write %s: %v\n", result.OutputFile, err)
+			fmt.Printf("%s[ERROR] Failed to write %s: %v\n", prefix, result.OutputFile, err)
 			errorCount++
+			summary.Errors = append(summary.Errors, ErrorSummary{
+				Agent: result.AgentName,
+				Error: fmt.Sprintf("failed to write %s: %v", result.OutputFile, err),
+			})
 			continue
 		}
 
-		fmt.Printf("  [OK] %s -> %s\n", result.AgentName, result.OutputFile)
+		var artifacts []string
+		for _, artifact := range result.Artifacts {
+			artifactPath := filepath.Join(outputDir, artifact.File)
+			if err := os.WriteFile(artifactPath, []byte(artifact.Content), 0600); err != nil {
+				fmt.Printf("%s[WARN] Failed to write %s: %v\n", prefix, artifact.File, err)
+				continue
+			}
+			artifacts = append(artifacts, artifact.File)
+		}
+
+		files := result.OutputFile
+		if len(artifacts) > 0 {
+			files += ", " + strings.Join(artifacts, ", ")
+		}
+		fmt.Printf("%s[OK] %s -> %s\n", prefix, result.AgentName, files)
+		printDiagnostics(prefix+"  ", result.Diagnostics)
 		successCount++
 
 		summary.Outputs = append(summary.Outputs, OutputSummary{
-			Agent: result.AgentName,
-			File:  result.OutputFile,
+			Agent:       result.AgentName,
+			File:        result.OutputFile,
+			Artifacts:   artifacts,
+			Diagnostics: result.Diagnostics,
 		})
 	}
 
-	// Write summary
-	summaryPath := filepath.Join(outputDir, "summary.json")
-	summaryData, _ := json.MarshalIndent(summary, "", "  ")
-	if err := os.WriteFile(summaryPath, summaryData, 0600); err != nil {
-		fmt.Printf("  [WARN] Failed to write summary.json: %v\n", err)
+	return summary, successCount, errorCount
+}
+
+// printDiagnostics prints one line per diagnostic.
+func printDiagnostics(prefix string, diags []agent.Diagnostic) {
+	for _, d := range diags {
+		fmt.Printf("%s[%s] %s\n", prefix, strings.ToUpper(d.Severity), d)
 	}
+}
 
-	fmt.Println()
-	fmt.Printf("Completed in %s: %d successful, %d errors\n", duration.Round(time.Millisecond), successCount, errorCount)
+// loadSummary reads summary.json from outputDir.
+func loadSummary(outputDir string) (Summary, error) {
+	var summary Summary
+	data, err := os.ReadFile(filepath.Join(outputDir, "summary.json"))
+	if err != nil {
+		return summary, err
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return summary, fmt.Errorf("failed to parse summary.json: %w", err)
+	}
+	return summary, nil
+}
 
-	if errorCount > 0 {
-		return fmt.Errorf("%d agent(s) failed", errorCount)
+// mergeSummary merges the results of a partial run into a previous summary.
+// Outputs and errors from the current run replace those of the same agent;
+// entries for agents that were not rerun are kept.
+func mergeSummary(prev, cur Summary) Summary {
+	replaced := make(map[string]bool)
+	for _, out := range cur.Outputs {
+		replaced[out.Agent] = true
+	}
+	for _, failure := range cur.Errors {
+		replaced[failure.Agent] = true
 	}
 
-	return nil
+	merged := cur
+	merged.Outputs = nil
+	merged.Errors = nil
+	for _, out := range prev.Outputs {
+		if !replaced[out.Agent] {
+			merged.Outputs = append(merged.Outputs, out)
+		}
+	}
+	for _, failure := range prev.Errors {
+		if !replaced[failure.Agent] {
+			merged.Errors = append(merged.Errors, failure)
+		}
+	}
+	merged.Outputs = append(merged.Outputs, cur.Outputs...)
+	merged.Errors = append(merged.Errors, cur.Errors...)
+	if merged.Outputs == nil {
+		merged.Outputs = []OutputSummary{}
+	}
+	return merged
+}
+
+// writeSummary writes summary.json into outputDir.
+func writeSummary(outputDir string, summary Summary) error {
+	summaryPath := filepath.Join(outputDir, "summary.json")
+	summaryData, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(summaryPath, summaryData, 0600)
 }
 
 // Summary holds metadata about the generation run.
@@ -184,10 +395,19 @@ type Summary struct {
 	GeneratedAt string          `json:"generated_at"`
 	Duration    string          `json:"duration"`
 	Outputs     []OutputSummary `json:"outputs"`
+	Errors      []ErrorSummary  `json:"errors,omitempty"`
 }
 
 // OutputSummary describes a generated output file.
 type OutputSummary struct {
+	Agent       string             `json:"agent"`
+	File        string             `json:"file"`
+	Artifacts   []string           `json:"artifacts,omitempty"`
+	Diagnostics []agent.Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// ErrorSummary describes an agent that failed to produce its output.
+type ErrorSummary struct {
 	Agent string `json:"agent"`
-	File  string `json:"file"`
+	Error string `json:"error"`
 }
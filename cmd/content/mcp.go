@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/agentplexus/agent-team-content/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+var mcpRoot string
+
+func newMCPCmd() *cobra.Command {
+	mcpCmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve the agents as Model Context Protocol tools over stdio",
+		RunE:  runMCP,
+	}
+
+	addAgentFlags(mcpCmd)
+	mcpCmd.Flags().StringVar(&mcpRoot, "root", ".", "Directory that tool calls may read conversation files from (empty disables path)")
+
+	return mcpCmd
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	srv := mcp.New(client, mcp.Config{
+		Name:      "content",
+		Version:   version,
+		ThemesDir: agentOpts.ThemesDir,
+		Root:      mcpRoot,
+		Options:   agentOpts,
+	})
+
+	// stdout carries the protocol; nothing else may be printed to it.
+	return srv.Serve(ctx, os.Stdin, os.Stdout)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/agentplexus/agent-team-content/internal/agent"
+	"github.com/agentplexus/agent-team-content/internal/thread"
+	"github.com/spf13/cobra"
+)
+
+// agentOpts holds the agent options set by flags on every command that runs
+// agents.
+var agentOpts agent.Options
+
+// addAgentFlags registers the model and agent option flags on cmd.
+func addAgentFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&model, "model", "claude-sonnet-4-20250514", "Claude model to use")
+	flags.StringVar(&agentOpts.SpecsDir, "specs", "", "Load agent system prompts from this specs directory (default: built-in prompts)")
+	flags.StringVar(&agentOpts.MarpTheme, "theme", "", "Marp theme name or CSS file")
+	flags.StringVar(&agentOpts.BrandKit, "brand", "", "Brand kit YAML file applied to every agent's prompt and checked against its output")
+	flags.StringVar(&agentOpts.ThemesDir, "themes", "themes", "Directory of theme CSS files that --theme and --revealjs-theme may name")
+	flags.StringVar(&agentOpts.TwitterRepair, "twitter-repair", agent.RepairLocal, "How to repair invalid tweets: local (split and renumber) or llm (targeted rewrite)")
+	flags.StringVar(&agentOpts.MarpRepair, "marp-repair", agent.RepairLocal, "How to repair Marp decks: local (frontmatter and empty slides) or llm (also rewrite overflowing slides and add missing notes)")
+	flags.BoolVar(&agentOpts.LinkedInUnicode, "linkedin-unicode", false, "Render LinkedIn headings and emphasis as Unicode bold/italic instead of plain text")
+	flags.IntVar(&agentOpts.MastodonMaxLength, "mastodon-max-length", thread.MastodonMaxLength, "Post length limit of the Mastodon instance")
+	flags.StringVar(&agentOpts.MastodonContentWarning, "mastodon-cw", "", "Content warning for every Mastodon post (default: the model's suggestion, if any)")
+	flags.StringVar(&agentOpts.RedditSubreddit, "reddit-subreddit", agent.DefaultSubreddit, "Subreddit to write the Reddit post for")
+	flags.StringVar(&agentOpts.RedditStyle, "reddit-style", agent.RedditDiscussion, "Reddit post style: discussion, showcase, tutorial or question")
+	flags.StringVar(&agentOpts.DevToCanonicalURL, "devto-canonical-url", "", "canonical_url for the dev.to article (default: conversation metadata)")
+	flags.StringVar(&agentOpts.DevToSeries, "devto-series", "", "series for the dev.to article (default: conversation metadata)")
+	flags.StringVar(&agentOpts.SEOArticle, "seo-article", "", "Generated article (e.g. output/blog.md) for the seo agent to describe (default: the blog or devto article from the same run, else the conversation)")
+	flags.BoolVar(&agentOpts.EmbedDiagrams, "embed-diagrams", false, "Embed the diagram agent's Mermaid diagrams in devto.md and the Marp and Reveal.js decks")
+	flags.StringVar(&agentOpts.RevealTheme, "revealjs-theme", "", "Reveal.js theme name or custom theme CSS file (default: black)")
+	flags.StringVar(&agentOpts.RevealTransition, "revealjs-transition", "", "Reveal.js slide transition: none, fade, slide, convex, concave or zoom (default: slide)")
+	flags.BoolVar(&agentOpts.RevealSlideNumber, "revealjs-slide-number", false, "Show slide numbers in Reveal.js decks")
+	flags.StringSliceVar(&agentOpts.RevealPlugins, "revealjs-plugins", agent.DefaultRevealPlugins, "Reveal.js plugins to enable: notes, highlight, math, search, zoom (empty for none)")
+	flags.IntVar(&agentOpts.ScriptWPM, "script-wpm", agent.DefaultScriptWPM, "Speaking rate in words per minute for script chapter durations")
+}
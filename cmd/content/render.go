@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agentplexus/agent-team-content/internal/agent"
+	"github.com/agentplexus/agent-team-content/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renderInput   string
+	renderOutput  string
+	renderFormat  string
+	renderTargets string
+	renderOpts    render.Options
+
+	// renderOnGenerate holds the targets for generate --render.
+	renderOnGenerate string
+)
+
+func newRenderCmd() *cobra.Command {
+	renderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render Marp and Reveal.js decks to standalone HTML or PowerPoint",
+		Long: `Render Marp and Reveal.js Markdown decks to self-contained HTML files that
+open offline, or to PowerPoint (.pptx) files. Given an output directory,
+marp.md and revealjs.md are rendered to marp.html and revealjs.html (or
+.pptx) next to them.`,
+		RunE: runRender,
+	}
+
+	renderCmd.Flags().StringVarP(&renderInput, "input", "i", "", "Deck file, or output directory containing marp.md and revealjs.md")
+	renderCmd.Flags().StringVarP(&renderOutput, "output", "o", "", "Output file (single deck and target) or directory (default: next to the input)")
+	renderCmd.Flags().StringVar(&renderFormat, "format", "", "Deck format: marp or revealjs (default: detect from file name and frontmatter)")
+	renderCmd.Flags().StringVar(&renderTargets, "to", render.TargetHTML, "Comma-separated output targets: html, pptx")
+	renderCmd.Flags().StringVar(&renderOpts.MarpTheme, "theme", "", "Marp theme name or CSS file, overriding the deck's theme")
+	renderCmd.Flags().StringVar(&renderOpts.RevealTheme, "revealjs-theme", "", "Reveal.js theme name or CSS file, overriding the deck's theme")
+	renderCmd.Flags().StringVar(&renderOpts.ThemesDir, "themes", "themes", "Directory of theme CSS files that --theme and --revealjs-theme may name")
+	renderCmd.Flags().StringVar(&renderOpts.PPTXTemplate, "pptx-template", "", "PowerPoint file whose theme colors and fonts are used for pptx output")
+	if err := renderCmd.MarkFlagRequired("input"); err != nil {
+		panic(err)
+	}
+
+	return renderCmd
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	info, err := os.Stat(renderInput)
+	if err != nil {
+		return err
+	}
+	targets := splitList(renderTargets)
+	if err := render.CheckTargets(targets); err != nil {
+		return err
+	}
+
+	var inputs []string
+	outDir := renderOutput
+	if info.IsDir() {
+		for _, file := range []string{"marp.md", "revealjs.md"} {
+			if _, err := os.Stat(filepath.Join(renderInput, file)); err == nil {
+				inputs = append(inputs, filepath.Join(renderInput, file))
+			}
+		}
+		if len(inputs) == 0 {
+			return fmt.Errorf("no marp.md or revealjs.md found in %s", renderInput)
+		}
+		if outDir == "" {
+			outDir = renderInput
+		}
+	} else {
+		inputs = []string{renderInput}
+		if outDir == "" {
+			outDir = filepath.Dir(renderInput)
+		}
+	}
+
+	// A single output file may be named directly.
+	outFile := ""
+	if !info.IsDir() && len(targets) == 1 && renderOutput != "" && !isDir(renderOutput) {
+		outFile = renderOutput
+	} else if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, in := range inputs {
+		for _, target := range targets {
+			out := outFile
+			if out == "" {
+				out = filepath.Join(outDir, render.OutputFile(filepath.Base(in), target))
+			}
+			if err := renderFile(in, out, target); err != nil {
+				return err
+			}
+			fmt.Printf("[OK] %s -> %s\n", in, out)
+		}
+	}
+	return nil
+}
+
+// renderFile renders one deck file to an output file.
+func renderFile(in, out, target string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	format := renderFormat
+	if format == "" {
+		if format = render.Detect(in, string(data)); format == "" {
+			return fmt.Errorf("cannot detect deck format of %s; use --format", in)
+		}
+	}
+
+	rendered, err := render.To(target, format, string(data), renderOpts)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", in, err)
+	}
+	return os.WriteFile(out, rendered, 0600)
+}
+
+// isDir reports whether path is an existing directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// renderResults adds a rendering of each presentation result to each of
+// the given targets as artifacts. Decks that fail to render get a warning
+// diagnostic instead.
+func renderResults(results []agent.Result, targets []string, opts render.Options) []agent.Result {
+	for i, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		format := render.Detect(result.OutputFile, result.Content)
+		if format == "" {
+			continue
+		}
+
+		for _, target := range targets {
+			rendered, err := render.To(target, format, result.Content, opts)
+			if err != nil {
+				results[i].Diagnostics = append(results[i].Diagnostics, agent.Diagnostic{
+					Severity: agent.SeverityWarning,
+					Message:  fmt.Sprintf("failed to render %s: %v", target, err),
+				})
+				continue
+			}
+			results[i].Artifacts = append(results[i].Artifacts, agent.Artifact{
+				File:    render.OutputFile(result.OutputFile, target),
+				Content: string(rendered),
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/agentplexus/agent-team-content/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var serveConfig = server.DefaultConfig()
+
+func newServeCmd() *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the content team as a JSON HTTP API",
+		RunE:  runServe,
+	}
+
+	serveCmd.Flags().StringVar(&serveConfig.Addr, "addr", serveConfig.Addr, "Listen address")
+	serveCmd.Flags().IntVar(&serveConfig.Workers, "workers", serveConfig.Workers, "Number of jobs processed at once")
+	serveCmd.Flags().IntVar(&serveConfig.QueueSize, "queue", serveConfig.QueueSize, "Maximum number of queued jobs")
+	serveCmd.Flags().Int64Var(&serveConfig.MaxBodyBytes, "max-body", serveConfig.MaxBodyBytes, "Maximum request body size in bytes")
+	serveCmd.Flags().DurationVar(&serveConfig.JobRetention, "retention", serveConfig.JobRetention, "How long finished jobs are kept")
+	serveCmd.Flags().DurationVar(&serveConfig.ShutdownTimeout, "shutdown-timeout", serveConfig.ShutdownTimeout, "Grace period for running jobs on shutdown")
+	addAgentFlags(serveCmd)
+
+	return serveCmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	serveConfig.Options = agentOpts
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Serving content API on %s\n", serveConfig.Addr)
+
+	err = server.New(client, serveConfig).Run(ctx)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server error: %w", err)
+	}
+
+	fmt.Println("Server stopped")
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/agentplexus/agent-team-content/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+var (
+	themesDir    string
+	themesTarget string
+)
+
+func newThemesCmd() *cobra.Command {
+	themesCmd := &cobra.Command{
+		Use:   "themes",
+		Short: "List, show and validate presentation themes",
+	}
+	themesCmd.PersistentFlags().StringVar(&themesDir, "themes", "themes", "Directory of theme CSS files")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List built-in themes and themes in the themes directory",
+		Args:  cobra.NoArgs,
+		RunE:  runThemesList,
+	}
+	listCmd.Flags().StringVar(&themesTarget, "target", "", "Only list themes for this format: marp or revealjs")
+
+	showCmd := &cobra.Command{
+		Use:   "show <name|file.css>",
+		Short: "Print a theme's metadata and CSS",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runThemesShow,
+	}
+	showCmd.Flags().StringVar(&themesTarget, "target", "", "Format the theme is for: marp or revealjs (default: marp, then revealjs)")
+
+	validateCmd := &cobra.Command{
+		Use:   "validate [file.css...]",
+		Short: "Check that theme files declare valid metadata (default: every file in the themes directory)",
+		RunE:  runThemesValidate,
+	}
+
+	themesCmd.AddCommand(listCmd, showCmd, validateCmd)
+	return themesCmd
+}
+
+func runThemesList(cmd *cobra.Command, args []string) error {
+	registry, err := theme.Discover(themesDir)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTARGET\tSOURCE\tDESCRIPTION")
+	for _, t := range registry.Themes() {
+		if themesTarget != "" && t.Target != "" && t.Target != themesTarget {
+			continue
+		}
+		target := t.Target
+		if target == "" {
+			target = "any"
+		}
+		source := t.Path
+		switch {
+		case t.CSS == "":
+			source = "upstream"
+		case t.Builtin():
+			source = "built-in"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Name, target, source, t.Description)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, err := range registry.Invalid {
+		fmt.Printf("[WARN] %v\n", err)
+	}
+	return nil
+}
+
+func runThemesShow(cmd *cobra.Command, args []string) error {
+	registry, err := theme.Discover(themesDir)
+	if err != nil {
+		return err
+	}
+	targets := []string{theme.TargetMarp, theme.TargetReveal}
+	if themesTarget != "" {
+		targets = []string{themesTarget}
+	}
+	var t *theme.Theme
+	for _, target := range targets {
+		if t, err = registry.Resolve(target, args[0]); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Name:        %s\n", t.Name)
+	fmt.Printf("Target:      %s\n", t.Target)
+	fmt.Printf("Description: %s\n", t.Description)
+	switch {
+	case t.CSS == "":
+		fmt.Println("Source:      upstream (stylesheet not bundled)")
+		return nil
+	case t.Builtin():
+		fmt.Println("Source:      built-in")
+	default:
+		fmt.Printf("Source:      %s\n", t.Path)
+	}
+	fmt.Printf("\n%s", t.CSS)
+	return nil
+}
+
+func runThemesValidate(cmd *cobra.Command, args []string) error {
+	files := args
+	if len(files) == 0 {
+		var err error
+		if files, err = filepath.Glob(filepath.Join(themesDir, "*.css")); err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			fmt.Printf("No theme files in %s\n", themesDir)
+			return nil
+		}
+	}
+
+	var invalid int
+	for _, file := range files {
+		t, err := theme.Load(file)
+		if err != nil {
+			fmt.Printf("[ERROR] %v\n", err)
+			invalid++
+			continue
+		}
+		fmt.Printf("[OK] %s: %s\n", file, t.Name)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d theme files invalid", invalid, len(files))
+	}
+	return nil
+}
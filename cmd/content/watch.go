@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/agent-team-content/internal/agent"
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchInterval time.Duration
+	watchDebounce time.Duration
+)
+
+func newWatchCmd() *cobra.Command {
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Regenerate content when the conversation, specs or themes change",
+		RunE:  runWatch,
+	}
+
+	watchCmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input conversation file (JSON or Markdown)")
+	watchCmd.Flags().StringVarP(&outputDir, "output", "o", "./output", "Output directory")
+	watchCmd.Flags().StringVar(&agentList, "agents", "", "Comma-separated list of agents (default: all)")
+	addAgentFlags(watchCmd)
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 500*time.Millisecond, "How often to poll for changes")
+	watchCmd.Flags().DurationVar(&watchDebounce, "debounce", time.Second, "Quiet period after the last change before regenerating")
+	if err := watchCmd.MarkFlagRequired("input"); err != nil {
+		panic(err)
+	}
+
+	return watchCmd
+}
+
+// fileState is the modification fingerprint of a watched file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	selected := agent.ListAgents()
+	if agentList != "" {
+		selected = splitList(agentList)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Watching: %s\n", inputFile)
+	fmt.Printf("Output directory: %s\n", outputDir)
+	fmt.Println()
+
+	if err := regenerate(ctx, client, selected); err != nil {
+		fmt.Printf("[ERROR] %v\n", err)
+	}
+
+	state := snapshotWatched(selected)
+	pending := make(map[string]bool)
+	var lastChange time.Time
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			fmt.Println("Stopped watching")
+			return nil
+		case <-ticker.C:
+		}
+
+		current := snapshotWatched(selected)
+		for _, path := range changedFiles(state, current) {
+			pending[path] = true
+			lastChange = time.Now()
+		}
+		state = current
+
+		if len(pending) == 0 || time.Since(lastChange) < watchDebounce {
+			continue
+		}
+
+		var changed []string
+		for path := range pending {
+			changed = append(changed, path)
+		}
+		sort.Strings(changed)
+		pending = make(map[string]bool)
+
+		affected := affectedAgents(changed, selected)
+		fmt.Println()
+		fmt.Printf("Changed: %s\n", strings.Join(changed, ", "))
+		if len(affected) == 0 {
+			fmt.Println("  No agents affected")
+			continue
+		}
+		if err := regenerate(ctx, client, affected); err != nil {
+			fmt.Printf("[ERROR] %v\n", err)
+		}
+	}
+}
+
+// regenerate runs the given agents against the current input file, writes
+// their outputs, merges them into summary.json and prints what changed.
+func regenerate(ctx context.Context, client *llm.Client, agents []string) error {
+	conv, err := conversation.ParseFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse conversation: %w", err)
+	}
+
+	orchestrator, err := agent.NewOrchestratorWithAgents(client, agents, agentOpts)
+	if err != nil {
+		return fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+
+	fmt.Printf("Regenerating: %s\n", strings.Join(agents, ", "))
+
+	startTime := time.Now()
+	results := orchestrator.Generate(ctx, conv)
+	duration := time.Since(startTime)
+
+	previous := make(map[string]string)
+	for _, result := range results {
+		for file := range resultFiles(result) {
+			if data, err := os.ReadFile(filepath.Join(outputDir, file)); err == nil {
+				previous[file] = string(data)
+			}
+		}
+	}
+
+	summary, successCount, errorCount := writeResults(outputDir, inputFile, results, duration, "  ")
+	if prev, err := loadSummary(outputDir); err == nil {
+		summary = mergeSummary(prev, summary)
+	}
+	if err := writeSummary(outputDir, summary); err != nil {
+		fmt.Printf("  [WARN] Failed to write summary.json: %v\n", err)
+	}
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		files := resultFiles(result)
+		names := make([]string, 0, len(files))
+		for file := range files {
+			names = append(names, file)
+		}
+		sort.Strings(names)
+
+		for _, file := range names {
+			old, existed := previous[file]
+			switch {
+			case !existed:
+				fmt.Printf("  %s: new file\n", file)
+			case old == files[file]:
+				fmt.Printf("  %s: unchanged\n", file)
+			default:
+				added, removed := diffLines(old, files[file])
+				fmt.Printf("  %s: +%d -%d lines\n", file, added, removed)
+			}
+		}
+	}
+
+	fmt.Printf("Completed in %s: %d successful, %d errors\n", duration.Round(time.Millisecond), successCount, errorCount)
+	return nil
+}
+
+// resultFiles maps each file written for a result to its content.
+func resultFiles(result agent.Result) map[string]string {
+	files := map[string]string{result.OutputFile: result.Content}
+	for _, artifact := range result.Artifacts {
+		files[artifact.File] = artifact.Content
+	}
+	return files
+}
+
+// watchedFiles returns the files whose changes can affect the selected
+// agents: the input conversation, their spec files if --specs is set, theme
+// files and the article described by the seo agent.
+func watchedFiles(selected []string) []string {
+	files := []string{inputFile}
+	if agentOpts.SpecsDir != "" {
+		for _, name := range selected {
+			files = append(files, agent.SpecPath(agentOpts.SpecsDir, name))
+		}
+	}
+	for _, theme := range []string{agentOpts.MarpTheme, agentOpts.RevealTheme} {
+		if theme != "" {
+			files = append(files, theme)
+		}
+	}
+	if matches, err := filepath.Glob(filepath.Join(agentOpts.ThemesDir, "*.css")); err == nil {
+		files = append(files, matches...)
+	}
+	if agentOpts.SEOArticle != "" {
+		files = append(files, agentOpts.SEOArticle)
+	}
+	return files
+}
+
+// snapshotWatched records the current state of every watched file. Missing
+// files are recorded with a zero state so that creation is detected.
+func snapshotWatched(selected []string) map[string]fileState {
+	state := make(map[string]fileState)
+	for _, path := range watchedFiles(selected) {
+		info, err := os.Stat(path)
+		if err != nil {
+			state[path] = fileState{}
+			continue
+		}
+		state[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return state
+}
+
+// changedFiles returns the paths whose state differs between two snapshots.
+func changedFiles(before, after map[string]fileState) []string {
+	var changed []string
+	for path, st := range after {
+		if prev, ok := before[path]; !ok || prev != st {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	return changed
+}
+
+// affectedAgents maps changed files to the selected agents that depend on
+// them. A change to the input conversation affects every agent.
+func affectedAgents(changed, selected []string) []string {
+	affected := make(map[string]bool)
+	for _, path := range changed {
+		if path == inputFile {
+			return selected
+		}
+		for _, name := range selected {
+			if agentOpts.SpecsDir != "" && path == agent.SpecPath(agentOpts.SpecsDir, name) {
+				affected[name] = true
+			}
+		}
+		if path == agentOpts.SEOArticle {
+			affected["seo"] = true
+		}
+		if strings.EqualFold(filepath.Ext(path), ".css") {
+			for _, name := range agent.ThemedAgents() {
+				affected[name] = true
+			}
+		}
+	}
+
+	// Embedded diagrams come from the diagram agent's result in the same
+	// run, so it and the agents embedding its diagrams regenerate together.
+	// Likewise seo describes the blog or devto article from the same run
+	// when no article file is given.
+	var groups [][]string
+	if agentOpts.EmbedDiagrams {
+		groups = append(groups, append(agent.DiagramEmbeddingAgents(), "diagram"))
+	}
+	if source := agent.SEOArticleSource(selected); source != "" && agentOpts.SEOArticle == "" {
+		groups = append(groups, []string{source, "seo"})
+	}
+	for grown := true; grown; {
+		grown = false
+		for _, group := range groups {
+			if !slices.ContainsFunc(group, func(name string) bool { return affected[name] }) {
+				continue
+			}
+			for _, name := range group {
+				if !affected[name] {
+					affected[name] = true
+					grown = true
+				}
+			}
+		}
+	}
+
+	var names []string
+	for _, name := range selected {
+		if affected[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// diffLines returns the number of lines added and removed between two texts,
+// based on their longest common subsequence of lines.
+func diffLines(oldText, newText string) (added, removed int) {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	common := lcs[0][0]
+	return len(b) - common, len(a) - common
+}
@@ -3,11 +3,15 @@ module github.com/agentplexus/agent-team-content
 go 1.24.11
 
 require (
+	github.com/alecthomas/chroma/v2 v2.2.0
 	github.com/anthropics/anthropic-sdk-go v1.26.0
 	github.com/spf13/cobra v1.10.2
+	github.com/yuin/goldmark v1.8.6
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/dlclark/regexp2 v1.7.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.10 // indirect
 	github.com/tidwall/gjson v1.18.0 // indirect
@@ -2,7 +2,11 @@ package agent
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/agentplexus/agent-team-content/internal/brand"
 	"github.com/agentplexus/agent-team-content/internal/conversation"
 	"github.com/agentplexus/agent-team-content/internal/llm"
 )
@@ -19,11 +23,61 @@ type Agent interface {
 	Generate(ctx context.Context, conv *conversation.Conversation) (string, error)
 }
 
+// OutputGenerator is implemented by agents that return more than their
+// primary content, such as diagnostics from validating it or additional
+// output files.
+type OutputGenerator interface {
+	Agent
+
+	// GenerateOutput creates content from the conversation along with any
+	// diagnostics about it.
+	GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error)
+}
+
+// Output is the full output of an OutputGenerator.
+type Output struct {
+	Content     string
+	Artifacts   []Artifact
+	Diagnostics []Diagnostic
+}
+
+// Artifact is an additional file written alongside an agent's output file.
+type Artifact struct {
+	File    string
+	Content string
+}
+
+// Diagnostic severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Diagnostic describes a problem found, or a repair made, while validating
+// an agent's output.
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+// String formats the diagnostic for display.
+func (d Diagnostic) String() string {
+	if d.Location == "" {
+		return d.Message
+	}
+	return d.Location + ": " + d.Message
+}
+
 // BaseAgent provides common functionality for agents.
 type BaseAgent struct {
 	name       string
 	outputFile string
 	client     *llm.Client
+	specPrompt string
+	// brandPrompt holds brand kit guidelines appended to the system prompt.
+	brandPrompt string
 }
 
 // Name returns the agent's identifier.
@@ -36,15 +90,114 @@ func (a *BaseAgent) OutputFile() string {
 	return a.outputFile
 }
 
+// systemPrompt returns the system prompt loaded from the agent's spec, if
+// any, otherwise the built-in prompt, followed by any brand guidelines.
+func (a *BaseAgent) systemPrompt(builtin string) string {
+	if a.specPrompt != "" {
+		return a.specPrompt + a.brandPrompt
+	}
+	return builtin + a.brandPrompt
+}
+
+func (a *BaseAgent) setSpecPrompt(prompt string) {
+	a.specPrompt = prompt
+}
+
+func (a *BaseAgent) setBrandPrompt(prompt string) {
+	a.brandPrompt = prompt
+}
+
 // Result holds the output from an agent.
 type Result struct {
-	AgentName  string
-	OutputFile string
-	Content    string
-	Error      error
+	AgentName   string
+	OutputFile  string
+	Content     string
+	Artifacts   []Artifact
+	Diagnostics []Diagnostic
+	Error       error
 }
 
 // Options holds configuration for agent creation.
 type Options struct {
-	MarpTheme string // Path to custom Marp theme CSS
+	MarpTheme     string // Marp theme name or path to a theme CSS file
+	ThemesDir     string // Directory of theme CSS files that MarpTheme and RevealTheme may name
+	BrandKit      string // Path to a brand kit YAML file applied to every agent
+	MaxConcurrent int    // Maximum agents running at once across all Generate calls (0 = unlimited)
+	SpecsDir      string // Load system prompts from <SpecsDir>/agents/<name>.md when set
+	TwitterRepair string // How to repair invalid tweets: RepairLocal (default) or RepairLLM
+	MarpRepair    string // How to repair Marp decks: RepairLocal (default) or RepairLLM
+
+	LinkedInUnicode bool // Render Markdown emphasis in LinkedIn posts as Unicode bold/italic
+
+	MastodonMaxLength      int    // Post length limit of the Mastodon instance (0 = thread.MastodonMaxLength)
+	MastodonContentWarning string // Content warning for every Mastodon post (default: the model's suggestion, if any)
+
+	RedditSubreddit string // Subreddit to write Reddit posts for, with or without "r/" (default: DefaultSubreddit)
+	RedditStyle     string // Reddit post style, one of RedditStyles (default: discussion)
+
+	DevToCanonicalURL string // canonical_url for dev.to frontmatter (default: conversation metadata)
+	DevToSeries       string // series for dev.to frontmatter (default: conversation metadata)
+
+	SEOArticle string // Generated article for the seo agent to describe (default: SEOArticleSource's article from the same run)
+
+	EmbedDiagrams bool // Embed the diagram agent's Mermaid diagrams in devto.md and the slide decks
+
+	RevealTheme       string   // Reveal.js theme name or path to a custom theme CSS file (default: black)
+	RevealTransition  string   // Reveal.js slide transition, one of RevealTransitions (default: slide)
+	RevealSlideNumber bool     // Show slide numbers in Reveal.js decks
+	RevealPlugins     []string // Reveal.js plugins to enable, from RevealPlugins (nil: DefaultRevealPlugins)
+
+	ScriptWPM int // Speaking rate for script duration estimates in words per minute (0 = DefaultScriptWPM)
+}
+
+// Repair modes for agents that validate their output.
+const (
+	RepairLocal = "local" // Fix problems in Go, e.g. by splitting long posts
+	RepairLLM   = "llm"   // Ask the model to rewrite only the offending parts
+)
+
+// validate checks option values that agents cannot fall back from.
+func (o Options) validate() error {
+	switch o.TwitterRepair {
+	case "", RepairLocal, RepairLLM:
+	default:
+		return fmt.Errorf("unknown twitter repair mode: %s", o.TwitterRepair)
+	}
+	switch o.MarpRepair {
+	case "", RepairLocal, RepairLLM:
+	default:
+		return fmt.Errorf("unknown marp repair mode: %s", o.MarpRepair)
+	}
+	if o.DevToCanonicalURL != "" && !isAbsoluteURL(o.DevToCanonicalURL) {
+		return fmt.Errorf("dev.to canonical URL must be an absolute http(s) URL: %s", o.DevToCanonicalURL)
+	}
+	if o.RevealTransition != "" && !slices.Contains(RevealTransitions, o.RevealTransition) {
+		return fmt.Errorf("unknown reveal.js transition: %s (expected one of %s)", o.RevealTransition, strings.Join(RevealTransitions, ", "))
+	}
+	for _, plugin := range o.RevealPlugins {
+		if !slices.Contains(RevealPlugins, plugin) {
+			return fmt.Errorf("unknown reveal.js plugin: %s (expected one of %s)", plugin, strings.Join(RevealPlugins, ", "))
+		}
+	}
+	if o.MastodonMaxLength < 0 {
+		return fmt.Errorf("mastodon max length must be positive: %d", o.MastodonMaxLength)
+	}
+	if o.RedditSubreddit != "" && !subredditPattern.MatchString(normalizeSubreddit(o.RedditSubreddit)) {
+		return fmt.Errorf("invalid subreddit name: %s", o.RedditSubreddit)
+	}
+	if o.RedditStyle != "" && !slices.Contains(RedditStyles, o.RedditStyle) {
+		return fmt.Errorf("unknown reddit style: %s (expected one of %s)", o.RedditStyle, strings.Join(RedditStyles, ", "))
+	}
+	if o.ScriptWPM < 0 {
+		return fmt.Errorf("script speaking rate must be positive: %d", o.ScriptWPM)
+	}
+	return nil
+}
+
+// brandKit loads the BrandKit file, or returns nil if none is set.
+func (o Options) brandKit() (*brand.Kit, error) {
+	if o.BrandKit == "" {
+		return nil, nil
+	}
+	return brand.Load(o.BrandKit)
 }
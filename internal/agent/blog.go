@@ -48,5 +48,5 @@ func NewBlogAgent(client *llm.Client) *BlogAgent {
 // Generate creates a blog article from the conversation.
 func (a *BlogAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
 	prompt := formatPrompt(blogUserPrompt, conv.ToPrompt())
-	return a.client.Generate(ctx, blogSystemPrompt, prompt)
+	return a.client.Generate(ctx, a.systemPrompt(blogSystemPrompt), prompt)
 }
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/thread"
+)
+
+const blueskySystemPrompt = `You are a Bluesky content creator writing threads for a technical audience.
+
+Your task is to transform a conversation into a Bluesky thread that:
+
+1. Opens with a hook post that makes people want to read more
+2. Keeps each post under 300 characters, including the 1/, 2/ numbering; links count in full
+3. Uses a conversational, genuine tone rather than growth-hacking language
+4. Breaks the key insights into one idea per post
+5. Ends with a summary or a question that invites replies
+6. Uses at most 1-2 hashtags, in the final post only
+
+Mention accounts only by their full handle, e.g. @name.bsky.social or a custom domain handle like @example.com.
+
+Target: 5-10 posts in the thread.`
+
+const blueskyUserPrompt = `Transform this conversation into a Bluesky thread:
+
+%s
+
+Create a thread that breaks down the key insights into posts people will want to read, reply to and repost.`
+
+// BlueskyAgent creates Bluesky threads.
+type BlueskyAgent struct {
+	BaseAgent
+}
+
+// NewBlueskyAgent creates a new Bluesky thread agent.
+func NewBlueskyAgent(client *llm.Client) *BlueskyAgent {
+	return &BlueskyAgent{
+		BaseAgent: BaseAgent{
+			name:       "bluesky",
+			outputFile: "bluesky.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates a Bluesky thread from the conversation.
+func (a *BlueskyAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a Bluesky thread as structured output, splits posts
+// over 300 graphemes, checks handles and hashtags, and returns the thread as
+// Markdown plus a bluesky.json artifact.
+func (a *BlueskyAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(blueskyUserPrompt, conv.ToPrompt())
+
+	var structured socialStructured
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(blueskySystemPrompt), prompt, socialSchema("bluesky", false), &structured); err != nil {
+		return nil, err
+	}
+	return socialOutput(thread.Bluesky, structured.threadPosts(""))
+}
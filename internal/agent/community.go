@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// promotionalPattern matches marketing language that Hacker News and Reddit
+// readers treat as spam.
+var promotionalPattern = regexp.MustCompile(`(?i)\b(?:revolutionar(?:y|ize)|game[- ]chang(?:ing|er)|groundbreaking|cutting[- ]edge|best[- ]in[- ]class|world[- ]class|next[- ]gen(?:eration)?|disruptive|supercharge[sd]?|unleash(?:es)?|skyrocket|must[- ]have|blazing(?:ly)? fast|10x|ultimate|incredible|amazing|sign up (?:now|today)|limited time|don't miss|act now|check it out|free trial)\b`)
+
+const communityTitleFixupPrompt = `This %s title breaks the following rules:
+
+%s
+
+Title: %s
+
+Rewrite the title so it follows every rule: plain and factual, no hype, no exclamation marks. Return only the rewritten title.`
+
+// promotional returns the distinct promotional phrases in text.
+func promotional(text string) []string {
+	var (
+		phrases []string
+		seen    = map[string]bool{}
+	)
+	for _, phrase := range promotionalPattern.FindAllString(text, -1) {
+		if key := strings.ToLower(phrase); !seen[key] {
+			seen[key] = true
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
+
+// titleProblems reports a title that is empty, longer than maxLength
+// characters, or uses exclamation marks or promotional language.
+func titleProblems(title string, maxLength int) []string {
+	var problems []string
+	if title == "" {
+		return []string{"title is empty"}
+	}
+	if length := len([]rune(title)); length > maxLength {
+		problems = append(problems, fmt.Sprintf("title is %d characters, limit is %d", length, maxLength))
+	}
+	if strings.Contains(title, "!") {
+		problems = append(problems, "title uses exclamation marks")
+	}
+	if phrases := promotional(title); len(phrases) > 0 {
+		problems = append(problems, fmt.Sprintf("title uses promotional language: %s", strings.Join(phrases, ", ")))
+	}
+	return problems
+}
+
+// communityTitle checks a title for site, asks the model for one rewrite if
+// it breaks the rules, and reports any problems left as errors. normalize
+// is applied to the model's title and to the rewrite.
+func (a *BaseAgent) communityTitle(ctx context.Context, system, site, title string, maxLength int, normalize func(string) string) (string, []Diagnostic) {
+	title = normalize(title)
+	problems := titleProblems(title, maxLength)
+	if len(problems) == 0 {
+		return title, nil
+	}
+
+	var diags []Diagnostic
+	prompt := fmt.Sprintf(communityTitleFixupPrompt, site, strings.Join(problems, "\n"), title)
+	rewritten, err := a.client.Generate(ctx, system, prompt)
+	if err != nil {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "title",
+			Message:  fmt.Sprintf("fix-up request failed: %v", err),
+		})
+	} else {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "title",
+			Message:  "rewritten by the model: " + strings.Join(problems, "; "),
+		})
+		title = normalize(strings.Trim(strings.TrimSpace(rewritten), `"`))
+	}
+
+	for _, problem := range titleProblems(title, maxLength) {
+		diags = append(diags, Diagnostic{Severity: SeverityError, Location: "title", Message: problem})
+	}
+	return title, diags
+}
+
+// promotionalDiagnostics warns about promotional language in the text at
+// location.
+func promotionalDiagnostics(location, text string) []Diagnostic {
+	phrases := promotional(text)
+	if len(phrases) == 0 {
+		return nil
+	}
+	return []Diagnostic{{
+		Severity: SeverityWarning,
+		Location: location,
+		Message:  fmt.Sprintf("promotional language: %s", strings.Join(phrases, ", ")),
+	}}
+}
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/agentplexus/agent-team-content/internal/conversation"
 	"github.com/agentplexus/agent-team-content/internal/llm"
@@ -41,21 +42,83 @@ Create a developer-focused article that provides technical insights, code exampl
 // DevToAgent creates technical articles for dev.to.
 type DevToAgent struct {
 	BaseAgent
+	canonicalURL string
+	series       string
 }
 
-// NewDevToAgent creates a new dev.to article agent.
-func NewDevToAgent(client *llm.Client) *DevToAgent {
+// NewDevToAgent creates a new dev.to article agent. canonicalURL and series,
+// when set, are written to the article frontmatter and take precedence over
+// the conversation's canonical_url and series metadata.
+func NewDevToAgent(client *llm.Client, canonicalURL, series string) *DevToAgent {
 	return &DevToAgent{
 		BaseAgent: BaseAgent{
 			name:       "devto",
 			outputFile: "devto.md",
 			client:     client,
 		},
+		canonicalURL: canonicalURL,
+		series:       series,
 	}
 }
 
 // Generate creates a dev.to article from the conversation.
 func (a *DevToAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a dev.to article and validates its frontmatter,
+// normalizing tags, published and description. It fails if the frontmatter
+// is missing or cannot be parsed.
+func (a *DevToAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
 	prompt := formatPrompt(devtoUserPrompt, conv.ToPrompt())
-	return a.client.Generate(ctx, devtoSystemPrompt, prompt)
+	article, err := a.client.Generate(ctx, a.systemPrompt(devtoSystemPrompt), prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	src, body, ok := splitFrontmatter(article)
+	if !ok {
+		return nil, fmt.Errorf("devto.md frontmatter: article does not start with a --- delimited frontmatter block")
+	}
+
+	fm, repaired, err := parseDevtoFrontmatter(src)
+	if err != nil {
+		return nil, fmt.Errorf("devto.md frontmatter: %w", err)
+	}
+
+	var diags []Diagnostic
+	if repaired {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "frontmatter",
+			Message:  "invalid YAML, values were quoted to repair it",
+		})
+	}
+
+	opts := devtoFrontmatterOptions{
+		CanonicalURL: a.canonicalURL,
+		Series:       a.series,
+		Title:        conv.Title,
+	}
+	if opts.CanonicalURL == "" && isAbsoluteURL(conv.Metadata["canonical_url"]) {
+		opts.CanonicalURL = conv.Metadata["canonical_url"]
+	}
+	if opts.Series == "" {
+		opts.Series = conv.Metadata["series"]
+	}
+
+	fixes, err := fm.normalize(opts)
+	diags = append(diags, fixes...)
+	if err != nil {
+		return nil, fmt.Errorf("devto.md frontmatter: %w", err)
+	}
+
+	return &Output{
+		Content:     "---\n" + fm.String() + "---\n\n" + body,
+		Diagnostics: diags,
+	}, nil
 }
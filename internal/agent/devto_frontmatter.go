@@ -0,0 +1,303 @@
+package agent
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/agentplexus/agent-team-content/internal/deck"
+	"gopkg.in/yaml.v3"
+)
+
+// dev.to frontmatter limits enforced by the devto agent.
+const (
+	devtoMaxTags           = 4
+	devtoMaxDescriptionLen = 160 // Longer descriptions are cut off in previews
+)
+
+// devtoFrontmatter is the parsed frontmatter of a dev.to article. Keys are
+// kept in their original order so unknown keys survive normalization.
+type devtoFrontmatter struct {
+	node *yaml.Node // Mapping node
+}
+
+// devtoFrontmatterOptions are values set on the frontmatter regardless of
+// what the model wrote.
+type devtoFrontmatterOptions struct {
+	CanonicalURL string
+	Series       string
+	Title        string // Used when the model omitted a title
+}
+
+// splitFrontmatter separates a Markdown document into its frontmatter and
+// body. A ```markdown fence wrapping the whole document is removed first.
+func splitFrontmatter(doc string) (string, string, bool) {
+	doc = strings.TrimSpace(deck.UnwrapFence(doc))
+	if !strings.HasPrefix(doc, "---\n") {
+		return "", doc, false
+	}
+
+	rest := doc[len("---\n"):]
+	for offset := 0; offset <= len(rest); {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		if strings.TrimRight(line, " \t\r") == "---" {
+			body := ""
+			if end := offset + len(line) + 1; end < len(rest) {
+				body = rest[end:]
+			}
+			return rest[:offset], strings.TrimLeft(body, "\n"), true
+		}
+		if offset+len(line) >= len(rest) {
+			break
+		}
+		offset += len(line) + 1
+	}
+	return "", doc, false
+}
+
+// frontmatterLine matches a top-level "key: value" line.
+var frontmatterLine = regexp.MustCompile(`^([A-Za-z_][\w-]*):\s*(.*)$`)
+
+// parseDevtoFrontmatter parses frontmatter YAML. If it is not valid YAML,
+// typically because a title contains an unquoted colon, each top-level
+// "key: value" line is quoted and parsing is retried. It reports whether
+// that repair was needed.
+func parseDevtoFrontmatter(src string) (*devtoFrontmatter, bool, error) {
+	fm, err := decodeFrontmatter(src)
+	if err == nil {
+		return fm, false, nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(src, "\n") {
+		m := frontmatterLine.FindStringSubmatch(line)
+		if m == nil {
+			if strings.TrimSpace(line) != "" {
+				return nil, false, fmt.Errorf("invalid YAML: %w", err)
+			}
+			continue
+		}
+		lines = append(lines, m[1]+": "+quoteScalar(m[2]))
+	}
+	fm, retryErr := decodeFrontmatter(strings.Join(lines, "\n"))
+	if retryErr != nil {
+		return nil, false, fmt.Errorf("invalid YAML: %w", err)
+	}
+	return fm, true, nil
+}
+
+func decodeFrontmatter(src string) (*devtoFrontmatter, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(src), &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("frontmatter is not a key/value mapping")
+	}
+	return &devtoFrontmatter{node: doc.Content[0]}, nil
+}
+
+// quoteScalar double-quotes a raw value unless it is empty, a boolean, or
+// already quoted.
+func quoteScalar(v string) string {
+	v = strings.TrimSpace(v)
+	switch {
+	case v == "", v == "true", v == "false":
+		return v
+	case len(v) >= 2 && (v[0] == '"' && v[len(v)-1] == '"' || v[0] == '\'' && v[len(v)-1] == '\''):
+		return v
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
+
+// get returns the value node for key, or nil.
+func (f *devtoFrontmatter) get(key string) *yaml.Node {
+	for i := 0; i+1 < len(f.node.Content); i += 2 {
+		if f.node.Content[i].Value == key {
+			return f.node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// set replaces the value for key, appending the key if it is missing.
+func (f *devtoFrontmatter) set(key string, value *yaml.Node) {
+	for i := 0; i+1 < len(f.node.Content); i += 2 {
+		if f.node.Content[i].Value == key {
+			f.node.Content[i+1] = value
+			return
+		}
+	}
+	f.node.Content = append(f.node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// remove deletes key if present.
+func (f *devtoFrontmatter) remove(key string) {
+	for i := 0; i+1 < len(f.node.Content); i += 2 {
+		if f.node.Content[i].Value == key {
+			f.node.Content = append(f.node.Content[:i], f.node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// str returns the string value for key.
+func (f *devtoFrontmatter) str(key string) string {
+	if n := f.get(key); n != nil && n.Kind == yaml.ScalarNode {
+		return strings.TrimSpace(n.Value)
+	}
+	return ""
+}
+
+func stringNode(v string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v, Style: yaml.DoubleQuotedStyle}
+}
+
+// normalize validates the frontmatter against dev.to's rules and fixes what
+// it can: tags are lowercased, stripped to alphanumerics and capped at four,
+// published defaults to false, and long descriptions are shortened. It
+// returns an error when a required field cannot be recovered.
+func (f *devtoFrontmatter) normalize(opts devtoFrontmatterOptions) ([]Diagnostic, error) {
+	var diags []Diagnostic
+	warn := func(format string, args ...any) {
+		diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: "frontmatter", Message: fmt.Sprintf(format, args...)})
+	}
+
+	title := f.str("title")
+	if title == "" {
+		if opts.Title == "" {
+			return diags, fmt.Errorf("missing title")
+		}
+		title = opts.Title
+		warn("missing title, using the conversation title")
+	}
+	f.set("title", stringNode(title))
+
+	if n := f.get("published"); n == nil || n.Kind != yaml.ScalarNode || n.Value != "false" {
+		if n != nil {
+			warn("published must be false for generated drafts, was %q", n.Value)
+		}
+		f.set("published", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"})
+	}
+
+	description := f.str("description")
+	switch {
+	case description == "":
+		warn("missing description")
+	case utf8.RuneCountInString(description) > devtoMaxDescriptionLen:
+		warn("description is %d characters, shortened to %d", utf8.RuneCountInString(description), devtoMaxDescriptionLen)
+		f.set("description", stringNode(truncateWords(description, devtoMaxDescriptionLen)))
+	default:
+		f.set("description", stringNode(description))
+	}
+
+	if n := f.get("tags"); n != nil {
+		raw := tagValues(n)
+		tags := normalizeTags(raw)
+		if len(tags) == 0 {
+			warn("no usable tags")
+			f.remove("tags")
+		} else {
+			if strings.Join(tags, ",") != strings.Join(raw, ",") {
+				warn("tags normalized from %q to %q", strings.Join(raw, ", "), strings.Join(tags, ", "))
+			}
+			f.set("tags", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: strings.Join(tags, ", ")})
+		}
+	} else {
+		warn("no tags")
+	}
+
+	switch {
+	case opts.CanonicalURL != "":
+		f.set("canonical_url", stringNode(opts.CanonicalURL))
+	case f.str("canonical_url") != "":
+		if !isAbsoluteURL(f.str("canonical_url")) {
+			warn("removed invalid canonical_url %q", f.str("canonical_url"))
+			f.remove("canonical_url")
+		}
+	}
+	if opts.Series != "" {
+		f.set("series", stringNode(opts.Series))
+	}
+
+	return diags, nil
+}
+
+// String renders the frontmatter as YAML without the --- delimiters.
+func (f *devtoFrontmatter) String() string {
+	out, err := yaml.Marshal(f.node)
+	if err != nil {
+		// A node decoded by yaml.v3 and edited with scalars always marshals.
+		panic(err)
+	}
+	return string(out)
+}
+
+// tagValues returns the raw tags from a comma-separated string or a list.
+func tagValues(n *yaml.Node) []string {
+	var raw []string
+	switch n.Kind {
+	case yaml.ScalarNode:
+		raw = strings.Split(n.Value, ",")
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			raw = append(raw, item.Value)
+		}
+	}
+	var tags []string
+	for _, t := range raw {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// normalizeTags lowercases tags, removes everything but letters and digits,
+// drops duplicates and keeps at most devtoMaxTags.
+func normalizeTags(raw []string) []string {
+	var (
+		tags []string
+		seen = make(map[string]bool)
+	)
+	for _, t := range raw {
+		tag := strings.Map(func(r rune) rune {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, t)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+		if len(tags) == devtoMaxTags {
+			break
+		}
+	}
+	return tags
+}
+
+// truncateWords shortens s to at most limit runes, cutting at a word
+// boundary and adding an ellipsis.
+func truncateWords(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	cut := string(runes[:limit-1])
+	if i := strings.LastIndexAny(cut, " \t"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:.-") + "…"
+}
+
+// isAbsoluteURL reports whether s is an absolute http or https URL.
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
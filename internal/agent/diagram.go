@@ -0,0 +1,278 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/deck"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/mermaid"
+)
+
+const diagramSystemPrompt = `You are a technical illustrator drawing Mermaid diagrams of the concepts discussed in a technical conversation.
+
+Your task is to draw 2-5 diagrams that:
+
+1. Each explain one concept: a flowchart for a process or decision, a sequence diagram for interactions between components over time, a class diagram for types and their relationships
+2. Use only flowchart, sequenceDiagram or classDiagram
+3. Name boxes, participants and classes after the things in the conversation
+4. Stay readable: at most 12 nodes, participants or classes each
+5. Are tied to the section or idea of the article they illustrate
+
+Mermaid rules:
+- Start with "flowchart TD" or "flowchart LR", "sequenceDiagram" or "classDiagram"
+- Wrap node text containing brackets, parentheses or quotes in double quotes: A["run (twice)"]
+- Never use the lowercase word end as a node ID
+- Every sequence message has text: Client->>Server: request
+- Close every subgraph, loop, alt and opt with end, and every class body with }
+- No styling, themes or init directives
+- Give the diagram source only, without a code fence`
+
+const diagramUserPrompt = `Draw Mermaid diagrams of the concepts in this conversation:
+
+%s`
+
+const diagramFixupPrompt = `These Mermaid diagrams have syntax errors:
+
+%s
+
+Return each diagram with the errors fixed, keeping its content.`
+
+// diagramsFile is the artifact listing the diagrams as JSON, which other
+// agents' outputs embed from.
+const diagramsFile = "diagrams.json"
+
+// DiagramAgent creates Mermaid diagrams and checks their syntax.
+type DiagramAgent struct {
+	BaseAgent
+}
+
+// NewDiagramAgent creates a new Mermaid diagram agent.
+func NewDiagramAgent(client *llm.Client) *DiagramAgent {
+	return &DiagramAgent{
+		BaseAgent: BaseAgent{
+			name:       "diagram",
+			outputFile: "diagrams.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates Mermaid diagrams from the conversation.
+func (a *DiagramAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates diagrams as structured output and checks each with
+// the Mermaid syntax checker. Diagrams with errors are sent back to the
+// model once; errors that remain are reported, and those diagrams are
+// marked so they are never embedded.
+func (a *DiagramAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(diagramUserPrompt, conv.ToPrompt())
+	system := a.systemPrompt(diagramSystemPrompt)
+
+	var draft struct {
+		Diagrams []Diagram `json:"diagrams"`
+	}
+	if err := a.client.GenerateJSON(ctx, system, prompt, diagramSchema, &draft); err != nil {
+		return nil, err
+	}
+	if len(draft.Diagrams) == 0 {
+		return nil, fmt.Errorf("model returned no diagrams")
+	}
+
+	diagrams := draft.Diagrams
+	ids := map[string]int{}
+	for i := range diagrams {
+		diagrams[i].ID = diagramID(diagrams[i].Title, ids)
+		diagrams[i].Code = strings.TrimSpace(deck.UnwrapFence(diagrams[i].Code))
+		diagrams[i].check()
+	}
+
+	diags := a.fixDiagrams(ctx, system, diagrams, nil)
+	for _, d := range diagrams {
+		for _, e := range d.Errors {
+			diags = append(diags, Diagnostic{Severity: SeverityError, Location: d.ID, Message: e + "; not embedded"})
+		}
+	}
+
+	listing, err := json.MarshalIndent(diagrams, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode diagrams: %w", err)
+	}
+	return &Output{
+		Content:     diagramsMarkdown(diagrams),
+		Artifacts:   []Artifact{{File: diagramsFile, Content: string(listing) + "\n"}},
+		Diagnostics: diags,
+	}, nil
+}
+
+// diagramSchema is the structured output requested from the model.
+var diagramSchema = llm.Schema{
+	Name:        "mermaid_diagrams",
+	Description: "Record the Mermaid diagrams.",
+	Properties: map[string]any{
+		"diagrams": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title":       map[string]any{"type": "string"},
+					"type":        map[string]any{"type": "string", "enum": mermaid.Types},
+					"section":     map[string]any{"type": "string", "description": "Short title of the section or idea the diagram illustrates"},
+					"description": map[string]any{"type": "string", "description": "One sentence on what the diagram shows"},
+					"code":        map[string]any{"type": "string", "description": "Mermaid source without a code fence"},
+				},
+				"required": []string{"title", "type", "section", "description", "code"},
+			},
+		},
+	},
+	Required: []string{"diagrams"},
+}
+
+// diagramFixupSchema is the structured output requested for fixes.
+var diagramFixupSchema = llm.Schema{
+	Name:        "fixed_diagrams",
+	Description: "Record the fixed Mermaid diagrams.",
+	Properties: map[string]any{
+		"diagrams": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id":   map[string]any{"type": "string", "description": "ID of the diagram being fixed"},
+					"code": map[string]any{"type": "string", "description": "Fixed Mermaid source without a code fence"},
+				},
+				"required": []string{"id", "code"},
+			},
+		},
+	},
+	Required: []string{"diagrams"},
+}
+
+// Diagram is a Mermaid diagram, as listed in diagrams.json.
+type Diagram struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Type        string   `json:"type"`
+	Section     string   `json:"section,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Code        string   `json:"code"`
+	Errors      []string `json:"errors,omitempty"` // Syntax errors; diagrams with errors are not embedded
+}
+
+// Valid reports whether the diagram passed the syntax check.
+func (d Diagram) Valid() bool {
+	return len(d.Errors) == 0
+}
+
+// check records the diagram's syntax errors, and corrects its type to the
+// one its header declares.
+func (d *Diagram) check() {
+	d.Errors = nil
+	for _, e := range mermaid.Check(d.Code) {
+		d.Errors = append(d.Errors, e.Error())
+	}
+	if t := mermaid.Type(d.Code); t != "" {
+		d.Type = t
+	}
+}
+
+// fixDiagrams sends invalid diagrams back to the model once. A fix is kept
+// only if it passes the syntax check.
+func (a *DiagramAgent) fixDiagrams(ctx context.Context, system string, diagrams []Diagram, diags []Diagnostic) []Diagnostic {
+	var request strings.Builder
+	byID := map[string]int{}
+	for i, d := range diagrams {
+		if d.Valid() {
+			continue
+		}
+		byID[d.ID] = i
+		fmt.Fprintf(&request, "Diagram %s (%s):\n\n%s\n\n", d.ID, strings.Join(d.Errors, "; "), d.Code)
+	}
+	if len(byID) == 0 {
+		return diags
+	}
+
+	var fixed struct {
+		Diagrams []struct {
+			ID   string `json:"id"`
+			Code string `json:"code"`
+		} `json:"diagrams"`
+	}
+	prompt := fmt.Sprintf(diagramFixupPrompt, strings.TrimSpace(request.String()))
+	if err := a.client.GenerateJSON(ctx, system, prompt, diagramFixupSchema, &fixed); err != nil {
+		return append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("fix-up request failed: %v", err),
+		})
+	}
+
+	for _, f := range fixed.Diagrams {
+		i, ok := byID[f.ID]
+		if !ok {
+			continue
+		}
+		candidate := diagrams[i]
+		candidate.Code = strings.TrimSpace(deck.UnwrapFence(f.Code))
+		candidate.check()
+		if !candidate.Valid() {
+			continue
+		}
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: candidate.ID,
+			Message:  "fixed by the model: " + strings.Join(diagrams[i].Errors, "; "),
+		})
+		diagrams[i] = candidate
+	}
+	return diags
+}
+
+// diagramID returns a unique ID for a diagram titled title.
+func diagramID(title string, ids map[string]int) string {
+	id := "diagram"
+	if slug := strings.Trim(visualIDPattern.ReplaceAllString(strings.ToLower(title), "-"), "-"); slug != "" {
+		id += "-" + slug
+	}
+	ids[id]++
+	if n := ids[id]; n > 1 {
+		id = fmt.Sprintf("%s-%d", id, n)
+	}
+	return id
+}
+
+// mermaidBlock renders diagram source as a fenced mermaid code block.
+func mermaidBlock(code string) string {
+	return "```mermaid\n" + code + "\n```"
+}
+
+// diagramsMarkdown renders every diagram with its type, section and
+// description, noting those that failed the syntax check.
+func diagramsMarkdown(diagrams []Diagram) string {
+	var b strings.Builder
+	b.WriteString("# Diagrams\n")
+	for _, d := range diagrams {
+		fmt.Fprintf(&b, "\n## %s\n\n", d.Title)
+		details := []string{"**Type:** " + d.Type}
+		if d.Section != "" {
+			details = append(details, "**Section:** "+d.Section)
+		}
+		fmt.Fprintf(&b, "%s\n\n", strings.Join(details, "  \n"))
+		if d.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(d.Description))
+		}
+		if !d.Valid() {
+			fmt.Fprintf(&b, "> **Syntax errors:** %s\n\n", strings.Join(d.Errors, "; "))
+		}
+		fmt.Fprintf(&b, "%s\n", mermaidBlock(d.Code))
+	}
+	return b.String()
+}
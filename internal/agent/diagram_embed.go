@@ -0,0 +1,172 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/deck"
+)
+
+// diagramEmbedders embed diagrams into the output of the agents that
+// support it, returning the new content.
+var diagramEmbedders = map[string]func(content string, diagrams []Diagram) string{
+	"devto": embedArticleDiagrams,
+	"marp": func(content string, diagrams []Diagram) string {
+		return embedDeckDiagrams(deck.ParseMarp(content), diagrams, false)
+	},
+	"revealjs": func(content string, diagrams []Diagram) string {
+		return embedDeckDiagrams(deck.ParseReveal(content), diagrams, true)
+	},
+}
+
+// embeddableDiagrams returns the diagrams in a diagram agent result that
+// passed the syntax check.
+func embeddableDiagrams(result Result) []Diagram {
+	var diagrams []Diagram
+	for _, artifact := range result.Artifacts {
+		if artifact.File != diagramsFile {
+			continue
+		}
+		var all []Diagram
+		if err := json.Unmarshal([]byte(artifact.Content), &all); err != nil {
+			return nil
+		}
+		for _, d := range all {
+			if d.Valid() {
+				diagrams = append(diagrams, d)
+			}
+		}
+	}
+	return diagrams
+}
+
+// embedDiagrams adds diagrams to a successful result of an agent that
+// supports embedding.
+func embedDiagrams(result *Result, diagrams []Diagram) {
+	embed, ok := diagramEmbedders[result.AgentName]
+	if !ok || result.Error != nil || len(diagrams) == 0 {
+		return
+	}
+	result.Content = embed(result.Content, diagrams)
+	result.Diagnostics = append(result.Diagnostics, Diagnostic{
+		Severity: SeverityInfo,
+		Message:  fmt.Sprintf("embedded %d Mermaid diagrams", len(diagrams)),
+	})
+}
+
+// embedArticleDiagrams inserts each diagram at the end of the ## section
+// its section names, and appends the rest under a Diagrams section.
+func embedArticleDiagrams(article string, diagrams []Diagram) string {
+	lines := strings.Split(strings.TrimRight(article, "\n"), "\n")
+
+	// Find where each ## section ends, outside code fences.
+	type section struct {
+		title string
+		end   int // index of the line after the section
+	}
+	var (
+		sections []section
+		code     bool
+	)
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), "```") {
+			code = !code
+			continue
+		}
+		if code || !strings.HasPrefix(l, "## ") {
+			continue
+		}
+		if len(sections) > 0 {
+			sections[len(sections)-1].end = i
+		}
+		sections = append(sections, section{title: strings.TrimSpace(strings.TrimPrefix(l, "## "))})
+	}
+	if len(sections) > 0 {
+		sections[len(sections)-1].end = len(lines)
+	}
+
+	inserts := map[int][]string{}
+	var rest []string
+	for _, d := range diagrams {
+		placed := false
+		for _, s := range sections {
+			if sectionMatches(s.title, d.Section) {
+				inserts[s.end] = append(inserts[s.end], diagramFigure(d))
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			rest = append(rest, diagramFigure(d))
+		}
+	}
+
+	var out []string
+	for i := 0; i <= len(lines); i++ {
+		if figures := inserts[i]; len(figures) > 0 {
+			for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+				out = out[:len(out)-1]
+			}
+			for _, figure := range figures {
+				out = append(out, "", figure)
+			}
+			out = append(out, "")
+		}
+		if i < len(lines) {
+			out = append(out, lines[i])
+		}
+	}
+	if len(rest) > 0 {
+		out = append(out, "", "## Diagrams", "", strings.Join(rest, "\n\n"))
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n"
+}
+
+// sectionMatches reports whether a heading names the section a diagram
+// belongs to.
+func sectionMatches(heading, section string) bool {
+	heading = strings.ToLower(heading)
+	section = strings.ToLower(strings.TrimSpace(section))
+	if heading == "" || section == "" {
+		return false
+	}
+	return strings.Contains(heading, section) || strings.Contains(section, heading)
+}
+
+// diagramFigure renders a diagram for an article: its title in bold above
+// the Mermaid block.
+func diagramFigure(d Diagram) string {
+	return fmt.Sprintf("**%s**\n\n%s", d.Title, mermaidBlock(d.Code))
+}
+
+// embedDeckDiagrams adds a slide for each diagram before the deck's closing
+// slide, with its description as speaker notes.
+func embedDeckDiagrams(d *deck.Deck, diagrams []Diagram, reveal bool) string {
+	// Insert before the last horizontal slide, so vertical stacks stay
+	// together.
+	at := len(d.Slides)
+	for i := len(d.Slides) - 1; i > 0; i-- {
+		if !d.Slides[i].Vertical {
+			at = i
+			break
+		}
+	}
+
+	var slides []deck.Slide
+	for _, diagram := range diagrams {
+		content := fmt.Sprintf("## %s\n\n%s", diagram.Title, mermaidBlock(diagram.Code))
+		if diagram.Description != "" {
+			if reveal {
+				content += "\n\nNote: " + diagram.Description
+			} else {
+				content += "\n\n<!-- " + strings.ReplaceAll(diagram.Description, "--", "-") + " -->"
+			}
+		}
+		slides = append(slides, deck.NewSlide(0, content))
+	}
+
+	d.Slides = append(d.Slides[:at], append(slides, d.Slides[at:]...)...)
+	d.Renumber()
+	return d.Render()
+}
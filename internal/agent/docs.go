@@ -0,0 +1,251 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const docsSystemPrompt = `You are a technical writer turning a support conversation into a documentation page.
+
+Your task is to write a how-to or troubleshooting page that:
+
+1. Has a task-oriented title, e.g. "Fix 'connection refused' when starting the server"
+2. Describes the problem as the reader experiences it: symptoms, and the exact error messages quoted from the conversation
+3. Explains the root cause in plain language
+4. Gives numbered steps that fix the problem, each a single action with the commands or configuration needed
+5. Tells the reader how to verify that the fix worked
+6. Leaves out the back-and-forth of the conversation: dead ends, guesses and greetings
+
+Write for a reader who has the problem now and did not see the conversation. Use second person and the imperative ("Run", "Open", "Set").`
+
+const docsUserPrompt = `Turn this support conversation into a documentation page:
+
+%s`
+
+// DocsAgent creates how-to and troubleshooting documentation pages.
+type DocsAgent struct {
+	BaseAgent
+}
+
+// NewDocsAgent creates a new documentation page agent.
+func NewDocsAgent(client *llm.Client) *DocsAgent {
+	return &DocsAgent{
+		BaseAgent: BaseAgent{
+			name:       "docs",
+			outputFile: "docs.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates a documentation page from the conversation.
+func (a *DocsAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a documentation page as structured output and
+// renders it with problem, cause, steps and verification sections. Missing
+// sections are reported, as are error messages that do not appear in the
+// conversation.
+func (a *DocsAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(docsUserPrompt, conv.ToPrompt())
+
+	var page docsPage
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(docsSystemPrompt), prompt, docsSchema, &page); err != nil {
+		return nil, err
+	}
+	if len(page.Steps) == 0 {
+		return nil, fmt.Errorf("model returned no steps")
+	}
+
+	return &Output{Content: page.Markdown(), Diagnostics: page.check(conv)}, nil
+}
+
+// docsSchema is the structured output requested from the model.
+var docsSchema = llm.Schema{
+	Name:        "documentation_page",
+	Description: "Record the finished documentation page.",
+	Properties: map[string]any{
+		"title":   map[string]any{"type": "string", "description": "Task-oriented page title"},
+		"summary": map[string]any{"type": "string", "description": "One or two sentences on who the page is for and what it fixes"},
+		"kind":    map[string]any{"type": "string", "enum": []string{docsHowTo, docsTroubleshooting}},
+		"problem": map[string]any{"type": "string", "description": "Symptoms as the reader experiences them"},
+		"error_messages": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "Exact error messages quoted from the conversation",
+		},
+		"cause": map[string]any{"type": "string", "description": "Root cause in plain language"},
+		"steps": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"action":   map[string]any{"type": "string", "description": "The step as one imperative sentence"},
+					"details":  map[string]any{"type": "string", "description": "Explanation, in Markdown, or empty"},
+					"code":     map[string]any{"type": "string", "description": "Command or configuration for the step, or empty"},
+					"language": map[string]any{"type": "string", "description": "Language of the code, e.g. bash or yaml"},
+				},
+				"required": []string{"action"},
+			},
+		},
+		"verification": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "How to check that the fix worked",
+		},
+		"notes": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "Caveats, related problems or prevention tips",
+		},
+	},
+	Required: []string{"title", "summary", "kind", "problem", "cause", "steps", "verification"},
+}
+
+// Documentation page kinds.
+const (
+	docsHowTo           = "how-to"
+	docsTroubleshooting = "troubleshooting"
+)
+
+// docsPage is the model's structured documentation page.
+type docsPage struct {
+	Title         string     `json:"title"`
+	Summary       string     `json:"summary"`
+	Kind          string     `json:"kind"`
+	Problem       string     `json:"problem"`
+	ErrorMessages []string   `json:"error_messages"`
+	Cause         string     `json:"cause"`
+	Steps         []docsStep `json:"steps"`
+	Verification  []string   `json:"verification"`
+	Notes         []string   `json:"notes"`
+}
+
+// docsStep is one step of the fix.
+type docsStep struct {
+	Action   string `json:"action"`
+	Details  string `json:"details"`
+	Code     string `json:"code"`
+	Language string `json:"language"`
+}
+
+// Markdown renders the page. How-to pages describe a goal and background
+// where troubleshooting pages describe a problem and its cause.
+func (p docsPage) Markdown() string {
+	problem, cause, steps := "Problem", "Cause", "Solution"
+	if p.Kind == docsHowTo {
+		problem, cause, steps = "Goal", "Background", "Steps"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", strings.TrimSpace(p.Title))
+	if p.Summary != "" {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(p.Summary))
+	}
+
+	fmt.Fprintf(&b, "## %s\n\n%s\n\n", problem, strings.TrimSpace(p.Problem))
+	if len(p.ErrorMessages) > 0 {
+		fmt.Fprintf(&b, "```text\n%s\n```\n\n", strings.Join(p.ErrorMessages, "\n"))
+	}
+	if p.Cause != "" {
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", cause, strings.TrimSpace(p.Cause))
+	}
+
+	fmt.Fprintf(&b, "## %s\n\n", steps)
+	for i, s := range p.Steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, strings.TrimSpace(s.Action))
+		if s.Details != "" {
+			fmt.Fprintf(&b, "\n%s\n", indent(strings.TrimSpace(s.Details), "   "))
+		}
+		if s.Code != "" {
+			fmt.Fprintf(&b, "\n%s\n", indent(fmt.Sprintf("```%s\n%s\n```", s.Language, strings.TrimSpace(s.Code)), "   "))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(p.Verification) > 0 {
+		b.WriteString("## Verification\n\n")
+		for _, v := range p.Verification {
+			fmt.Fprintf(&b, "- %s\n", strings.TrimSpace(v))
+		}
+		b.WriteString("\n")
+	}
+	if len(p.Notes) > 0 {
+		b.WriteString("## Notes\n\n")
+		for _, n := range p.Notes {
+			fmt.Fprintf(&b, "- %s\n", strings.TrimSpace(n))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// check reports missing sections, and error messages that are not quoted
+// from the conversation.
+func (p docsPage) check(conv *conversation.Conversation) []Diagnostic {
+	var diags []Diagnostic
+	missing := func(location, value string) {
+		if strings.TrimSpace(value) == "" {
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: "missing"})
+		}
+	}
+	missing("title", p.Title)
+	missing("problem", p.Problem)
+	if p.Kind != docsHowTo {
+		missing("cause", p.Cause)
+	}
+	if len(p.Verification) == 0 {
+		diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: "verification", Message: "no way to verify the fix"})
+	}
+
+	for _, msg := range p.ErrorMessages {
+		if !inConversation(conv, msg) {
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				Location: "error_messages",
+				Message:  fmt.Sprintf("not quoted from the conversation: %q", msg),
+			})
+		}
+	}
+	return diags
+}
+
+// inConversation reports whether text appears in any message, ignoring
+// case and differences in whitespace.
+func inConversation(conv *conversation.Conversation, text string) bool {
+	text = normalizeSpace(text)
+	if text == "" {
+		return true
+	}
+	for _, msg := range conv.Messages {
+		if strings.Contains(normalizeSpace(msg.Content), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSpace lowercases text and collapses runs of whitespace.
+func normalizeSpace(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// indent prefixes every non-blank line of text.
+func indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,198 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/yuin/goldmark"
+)
+
+const faqSystemPrompt = `You are a technical writer turning a support conversation into frequently asked questions.
+
+Your task is to write an FAQ that:
+
+1. Has 4-10 questions, phrased the way a user would ask them, each ending with a question mark
+2. Covers the problems, causes and fixes discussed in the conversation, one topic per question
+3. Answers each question on its own, in 1-4 sentences, without referring to other questions or to the conversation
+4. Puts exact commands and settings in inline code
+5. Leaves out greetings, dead ends and anything the conversation did not establish
+
+The answers are published as FAQPage structured data, so they must be accurate and self-contained.`
+
+const faqUserPrompt = `Write an FAQ from this support conversation:
+
+%s`
+
+// faqMinQuestions is the fewest questions an FAQ should have.
+const faqMinQuestions = 3
+
+// FAQAgent creates FAQs with FAQPage structured data.
+type FAQAgent struct {
+	BaseAgent
+}
+
+// NewFAQAgent creates a new FAQ agent.
+func NewFAQAgent(client *llm.Client) *FAQAgent {
+	return &FAQAgent{
+		BaseAgent: BaseAgent{
+			name:       "faq",
+			outputFile: "faq.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates an FAQ from the conversation.
+func (a *FAQAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates an FAQ as structured output and returns it as
+// Markdown, with FAQPage JSON-LD in faq.json. Duplicate questions are
+// dropped and missing question marks added.
+func (a *FAQAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(faqUserPrompt, conv.ToPrompt())
+
+	var draft struct {
+		Title     string    `json:"title"`
+		Questions []faqItem `json:"questions"`
+	}
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(faqSystemPrompt), prompt, faqSchema, &draft); err != nil {
+		return nil, err
+	}
+
+	items, diags := cleanFAQ(draft.Questions)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("model returned no questions")
+	}
+	if len(items) < faqMinQuestions {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("%d questions, expected at least %d", len(items), faqMinQuestions),
+		})
+	}
+
+	jsonLD, err := faqJSONLD(items)
+	if err != nil {
+		return nil, err
+	}
+	title := firstNonEmpty(draft.Title, conv.Title, "Frequently Asked Questions")
+	return &Output{
+		Content:     faqMarkdown(title, items),
+		Artifacts:   []Artifact{{File: "faq.json", Content: jsonLD}},
+		Diagnostics: diags,
+	}, nil
+}
+
+// faqSchema is the structured output requested from the model.
+var faqSchema = llm.Schema{
+	Name:        "faq",
+	Description: "Record the finished FAQ.",
+	Properties: map[string]any{
+		"title": map[string]any{"type": "string", "description": "Title of the FAQ page"},
+		"questions": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"question": map[string]any{"type": "string"},
+					"answer":   map[string]any{"type": "string", "description": "Self-contained answer in Markdown"},
+				},
+				"required": []string{"question", "answer"},
+			},
+		},
+	},
+	Required: []string{"title", "questions"},
+}
+
+// faqItem is one question and its answer.
+type faqItem struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// cleanFAQ trims questions and answers, adds missing question marks, and
+// drops unanswered and duplicate questions.
+func cleanFAQ(items []faqItem) ([]faqItem, []Diagnostic) {
+	var (
+		cleaned []faqItem
+		diags   []Diagnostic
+		seen    = map[string]bool{}
+	)
+	for i, item := range items {
+		location := fmt.Sprintf("question %d", i+1)
+		item.Question = strings.TrimSpace(item.Question)
+		item.Answer = strings.TrimSpace(item.Answer)
+		switch key := normalizeSpace(strings.TrimRight(item.Question, "?")); {
+		case item.Question == "" || item.Answer == "":
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: "dropped: question or answer is empty"})
+			continue
+		case seen[key]:
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: "dropped: duplicate of an earlier question"})
+			continue
+		default:
+			seen[key] = true
+		}
+		if !strings.HasSuffix(item.Question, "?") {
+			item.Question += "?"
+			diags = append(diags, Diagnostic{Severity: SeverityInfo, Location: location, Message: "added a question mark"})
+		}
+		cleaned = append(cleaned, item)
+	}
+	return cleaned, diags
+}
+
+// faqMarkdown renders the FAQ with each question as a heading.
+func faqMarkdown(title string, items []faqItem) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, item := range items {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", item.Question, item.Answer)
+	}
+	return b.String()
+}
+
+// rawHTMLOmitted is the comment goldmark leaves in place of raw HTML.
+const rawHTMLOmitted = "<!-- raw HTML omitted -->"
+
+// faqJSONLD returns the FAQ as schema.org FAQPage JSON-LD. Answers are
+// converted to HTML, which FAQPage answer text may contain; raw HTML in
+// answers is dropped.
+func faqJSONLD(items []faqItem) (string, error) {
+	var questions []map[string]any
+	for _, item := range items {
+		var answer bytes.Buffer
+		if err := goldmark.Convert([]byte(item.Answer), &answer); err != nil {
+			return "", fmt.Errorf("failed to convert answer to HTML: %w", err)
+		}
+		questions = append(questions, map[string]any{
+			"@type": "Question",
+			"name":  item.Question,
+			"acceptedAnswer": map[string]any{
+				"@type": "Answer",
+				"text":  strings.TrimSpace(strings.ReplaceAll(answer.String(), rawHTMLOmitted, "")),
+			},
+		})
+	}
+
+	// < and > stay escaped as \u003c and \u003e, so the JSON-LD is safe to
+	// inline in a <script> element.
+	data, err := json.MarshalIndent(map[string]any{
+		"@context":   "https://schema.org",
+		"@type":      "FAQPage",
+		"mainEntity": questions,
+	}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode FAQ JSON-LD: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const hackernewsSystemPrompt = `You are a software engineer submitting your own project to Hacker News as a "Show HN" post.
+
+Your task is to transform a conversation into a Show HN submission that:
+
+1. Has a title of the form "Show HN: <name> – <what it is>", at most 80 characters in total
+2. States plainly what the project does; no hype, superlatives, exclamation marks or marketing language
+3. Has a first comment, posted by the author, that explains what you built, why, how it works and what is still rough
+4. Reads like one engineer talking to others: concrete technical details, honest tradeoffs, no calls to action
+5. Ends the first comment by asking for specific feedback
+
+Hacker News readers flag promotional posts. Write the first comment in plain text paragraphs; HN supports only *italics*, indented code and bare URLs.
+
+Target: a first comment of 150-400 words.`
+
+const hackernewsUserPrompt = `Transform this conversation into a Show HN submission:
+
+%s
+
+Create a submission that Hacker News readers will find technically interesting, not promotional.`
+
+// hackernewsTitleLength is Hacker News's title length limit.
+const hackernewsTitleLength = 80
+
+// showHNPattern matches a Show HN prefix written with any casing or
+// separator, e.g. "show hn -".
+var showHNPattern = regexp.MustCompile(`(?i)^show\s*hn\s*[:\-–—]?\s*`)
+
+// HackerNewsAgent creates Show HN submissions.
+type HackerNewsAgent struct {
+	BaseAgent
+}
+
+// NewHackerNewsAgent creates a new Hacker News submission agent.
+func NewHackerNewsAgent(client *llm.Client) *HackerNewsAgent {
+	return &HackerNewsAgent{
+		BaseAgent: BaseAgent{
+			name:       "hackernews",
+			outputFile: "hackernews.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates a Show HN submission from the conversation.
+func (a *HackerNewsAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a Show HN submission as structured output, enforces
+// the "Show HN: " prefix and 80-character title limit with one rewrite, and
+// warns about promotional language in the first comment.
+func (a *HackerNewsAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(hackernewsUserPrompt, conv.ToPrompt())
+	system := a.systemPrompt(hackernewsSystemPrompt)
+
+	var sub hackernewsSubmission
+	if err := a.client.GenerateJSON(ctx, system, prompt, hackernewsSchema, &sub); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(sub.FirstComment) == "" {
+		return nil, fmt.Errorf("model returned no first comment")
+	}
+
+	var diags []Diagnostic
+	sub.Title, diags = a.communityTitle(ctx, system, "Show HN", sub.Title, hackernewsTitleLength, showHNTitle)
+	diags = append(diags, promotionalDiagnostics("first comment", sub.FirstComment)...)
+	if sub.URL != "" && !isAbsoluteURL(sub.URL) {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "url",
+			Message:  fmt.Sprintf("not an absolute http(s) URL: %s", sub.URL),
+		})
+	}
+
+	return &Output{Content: sub.Markdown(), Diagnostics: diags}, nil
+}
+
+// hackernewsSchema is the structured output requested from the model.
+var hackernewsSchema = llm.Schema{
+	Name:        "show_hn_submission",
+	Description: "Record the finished Show HN submission.",
+	Properties: map[string]any{
+		"title":         map[string]any{"type": "string", "description": "Title starting with \"Show HN: \", at most 80 characters"},
+		"url":           map[string]any{"type": "string", "description": "Project URL from the conversation, or empty for a text post"},
+		"first_comment": map[string]any{"type": "string", "description": "The author's first comment, in plain text paragraphs"},
+	},
+	Required: []string{"title", "first_comment"},
+}
+
+// hackernewsSubmission is the model's structured submission.
+type hackernewsSubmission struct {
+	Title        string `json:"title"`
+	URL          string `json:"url"`
+	FirstComment string `json:"first_comment"`
+}
+
+// Markdown renders the submission with the title and URL to enter on the
+// submit form, followed by the first comment.
+func (s hackernewsSubmission) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", s.Title)
+	if s.URL != "" {
+		fmt.Fprintf(&b, "**URL:** %s\n\n", s.URL)
+	}
+	fmt.Fprintf(&b, "## First comment\n\n%s\n", strings.TrimSpace(s.FirstComment))
+	return b.String()
+}
+
+// showHNTitle normalizes a title to start with exactly "Show HN: ".
+func showHNTitle(title string) string {
+	title = strings.TrimSpace(showHNPattern.ReplaceAllString(strings.TrimSpace(title), ""))
+	if title == "" {
+		return ""
+	}
+	return "Show HN: " + title
+}
@@ -2,6 +2,8 @@ package agent
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/agentplexus/agent-team-content/internal/conversation"
 	"github.com/agentplexus/agent-team-content/internal/llm"
@@ -23,6 +25,7 @@ Format guidelines:
 - Use line breaks between paragraphs
 - Start with attention-grabbing first line
 - Include 3-5 relevant hashtags at the end
+- Plain text only: LinkedIn does not render Markdown headings, bold or links
 
 Tone: Professional but approachable, thought-leadership oriented.`
 
@@ -32,24 +35,93 @@ const linkedinUserPrompt = `Transform this conversation into a LinkedIn post:
 
 Create a compelling LinkedIn post that shares key insights professionally and encourages engagement.`
 
+const linkedinFixupPrompt = `This LinkedIn post breaks the following rules:
+
+%s
+
+Rewrite the post so it follows every rule, keeping its hook, key points and voice. Use plain text only (no Markdown) and put all hashtags on a single final line. Return only the rewritten post.`
+
 // LinkedInAgent creates LinkedIn posts.
 type LinkedInAgent struct {
 	BaseAgent
+	unicode bool
 }
 
-// NewLinkedInAgent creates a new LinkedIn post agent.
-func NewLinkedInAgent(client *llm.Client) *LinkedInAgent {
+// NewLinkedInAgent creates a new LinkedIn post agent. When unicode is set,
+// Markdown headings, bold and italics are converted to Unicode styled
+// characters instead of being stripped.
+func NewLinkedInAgent(client *llm.Client, unicode bool) *LinkedInAgent {
 	return &LinkedInAgent{
 		BaseAgent: BaseAgent{
 			name:       "linkedin",
 			outputFile: "linkedin.md",
 			client:     client,
 		},
+		unicode: unicode,
 	}
 }
 
 // Generate creates a LinkedIn post from the conversation.
 func (a *LinkedInAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a LinkedIn post, converts Markdown LinkedIn does
+// not render to plain text, moves hashtags to the end, and asks the model
+// for one rewrite when the length or hashtag count cannot be fixed locally.
+func (a *LinkedInAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
 	prompt := formatPrompt(linkedinUserPrompt, conv.ToPrompt())
-	return a.client.Generate(ctx, linkedinSystemPrompt, prompt)
+	raw, err := a.client.Generate(ctx, a.systemPrompt(linkedinSystemPrompt), prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	post, tags, diags := a.format(raw)
+
+	if problems := linkedinProblems(post, tags); len(problems) > 0 {
+		request := strings.Join(problems, "\n") + "\n\nPost:\n\n" + post
+		rewritten, err := a.client.Generate(ctx, a.systemPrompt(linkedinSystemPrompt), formatPrompt(linkedinFixupPrompt, request))
+		if err != nil {
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("fix-up request failed: %v", err),
+			})
+		} else {
+			// Diagnostics from the first draft no longer apply.
+			var fixed []Diagnostic
+			post, tags, fixed = a.format(rewritten)
+			diags = append([]Diagnostic{{
+				Severity: SeverityWarning,
+				Message:  "rewritten by the model: " + strings.Join(problems, " "),
+			}}, fixed...)
+		}
+	}
+
+	for _, problem := range linkedinProblems(post, tags) {
+		diags = append(diags, Diagnostic{Severity: SeverityError, Message: problem})
+	}
+	diags = append(diags, linkedinPreview(post)...)
+
+	return &Output{Content: post + "\n", Diagnostics: diags}, nil
+}
+
+// format converts raw model output into a LinkedIn-safe post and returns it
+// with its hashtags and diagnostics for the changes made.
+func (a *LinkedInAgent) format(raw string) (string, []string, []Diagnostic) {
+	var diags []Diagnostic
+	text, converted := linkedinPlainText(raw, a.unicode)
+	if converted > 0 {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityInfo,
+			Location: "formatting",
+			Message:  fmt.Sprintf("converted %d Markdown construct(s) to plain text", converted),
+		})
+	}
+
+	post, tags, tagDiags := linkedinHashtags(text)
+	return post, tags, append(diags, tagDiags...)
 }
@@ -0,0 +1,305 @@
+package agent
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LinkedIn post constraints checked by the linkedin agent.
+const (
+	linkedinPreviewLength = 210  // Characters shown before "see more"
+	linkedinPreviewLines  = 3    // Lines shown before "see more"
+	linkedinMaxLength     = 1300 // Target maximum post length
+	linkedinMinHashtags   = 3
+	linkedinMaxHashtags   = 5
+)
+
+var (
+	mdHeading          = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+	mdBullet           = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+	mdBold             = regexp.MustCompile(`\*\*([^*\n]+)\*\*|__([^_\n]+)__`)
+	mdItalicStar       = regexp.MustCompile(`\*([^*\s][^*\n]*?)\*`)
+	mdItalicUnderscore = regexp.MustCompile(`_([^_\s][^_\n]*?)_`)
+	mdLink             = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdCode             = regexp.MustCompile("`([^`\n]+)`")
+	mdRule             = regexp.MustCompile(`^\s*(?:-{3,}|\*{3,}|_{3,})\s*$`)
+	mdFence            = regexp.MustCompile("^\\s*(```|~~~)")
+	hashtagToken       = regexp.MustCompile(`(^|[^\w&#/])#([\p{L}\p{N}_]*[\p{L}_][\p{L}\p{N}_]*)`)
+)
+
+// linkedinPlainText converts Markdown that LinkedIn does not render into
+// plain text. Headings and bold become Unicode sans-serif bold and italics
+// become sans-serif italic when unicode is set; otherwise their markers are
+// dropped. It returns the text and the number of constructs converted.
+func linkedinPlainText(text string, unicode bool) (string, int) {
+	bold := func(s string) string { return s }
+	italic := bold
+	if unicode {
+		bold = unicodeBold
+		italic = unicodeItalic
+	}
+
+	var (
+		out       []string
+		converted int
+	)
+	for _, line := range strings.Split(text, "\n") {
+		if mdFence.MatchString(line) || mdRule.MatchString(line) {
+			converted++
+			continue
+		}
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			line = bold(m[1])
+			converted++
+		}
+		if mdBullet.MatchString(line) {
+			line = mdBullet.ReplaceAllString(line, "${1}• ")
+			converted++
+		}
+
+		line, converted = replaceCounting(mdLink, line, converted, func(m []string) string {
+			return m[1] + " (" + m[2] + ")"
+		})
+		line, converted = replaceCounting(mdCode, line, converted, func(m []string) string {
+			return m[1]
+		})
+		line, converted = replaceCounting(mdBold, line, converted, func(m []string) string {
+			return bold(m[1] + m[2])
+		})
+		line, converted = replaceEmphasis(mdItalicStar, line, converted, "*", italic)
+		line, converted = replaceEmphasis(mdItalicUnderscore, line, converted, "", italic)
+
+		out = append(out, line)
+	}
+
+	return strings.TrimSpace(collapseBlankLines(strings.Join(out, "\n"))), converted
+}
+
+// replaceCounting replaces every match of re in s using fn and adds the
+// number of replacements to count.
+func replaceCounting(re *regexp.Regexp, s string, count int, fn func([]string) string) (string, int) {
+	result := re.ReplaceAllStringFunc(s, func(match string) string {
+		count++
+		return fn(re.FindStringSubmatch(match))
+	})
+	return result, count
+}
+
+// replaceEmphasis replaces every match of re in s whose markers are not
+// part of a word, or next to one of the characters in adjacent, with the
+// matched text passed through fn, and adds the number of replacements to
+// count. Unlike a boundary group in the pattern, the check does not consume
+// the character after a match, so adjacent spans like "*one* *two*" both
+// convert.
+func replaceEmphasis(re *regexp.Regexp, s string, count int, adjacent string, fn func(string) string) (string, int) {
+	boundary := func(r rune) bool {
+		return !(r < utf8.RuneSelf && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))) && !strings.ContainsRune(adjacent, r)
+	}
+
+	var b strings.Builder
+	pos := 0
+	for pos < len(s) {
+		loc := re.FindStringSubmatchIndex(s[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		before, _ := utf8.DecodeLastRuneInString(s[:start])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if (start > 0 && !boundary(before)) || (end < len(s) && !boundary(after)) {
+			// Not emphasis; look for a span starting after this marker.
+			b.WriteString(s[pos : start+1])
+			pos = start + 1
+			continue
+		}
+		b.WriteString(s[pos:start])
+		b.WriteString(fn(s[pos+loc[2] : pos+loc[3]]))
+		pos = end
+		count++
+	}
+	b.WriteString(s[pos:])
+	return b.String(), count
+}
+
+// collapseBlankLines reduces runs of blank lines to a single blank line.
+func collapseBlankLines(s string) string {
+	var (
+		out   []string
+		blank bool
+	)
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if blank {
+				continue
+			}
+			blank = true
+			out = append(out, "")
+			continue
+		}
+		blank = false
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// linkedinHashtags moves hashtags used inline in the body into a single
+// hashtag line at the end of the post, removes duplicates and keeps at most
+// linkedinMaxHashtags. It returns the post, the hashtags kept, and
+// diagnostics describing the changes.
+func linkedinHashtags(text string) (string, []string, []Diagnostic) {
+	paragraphs := strings.Split(text, "\n\n")
+
+	// Trailing paragraphs made only of hashtags form the hashtag block.
+	end := len(paragraphs)
+	for end > 0 && isHashtagLine(paragraphs[end-1]) {
+		end--
+	}
+	body := strings.Join(paragraphs[:end], "\n\n")
+
+	var (
+		tags  []string
+		seen  = make(map[string]bool)
+		diags []Diagnostic
+	)
+	add := func(tag string) {
+		key := strings.ToLower(tag)
+		if !seen[key] {
+			seen[key] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	inline := 0
+	body = hashtagToken.ReplaceAllStringFunc(body, func(match string) string {
+		m := hashtagToken.FindStringSubmatch(match)
+		inline++
+		add(m[2])
+		return m[1] + m[2]
+	})
+	if inline > 0 {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "hashtags",
+			Message:  fmt.Sprintf("moved %d inline hashtag(s) to the end of the post", inline),
+		})
+	}
+
+	for _, p := range paragraphs[end:] {
+		for _, m := range hashtagToken.FindAllStringSubmatch(p, -1) {
+			add(m[2])
+		}
+	}
+
+	if len(tags) > linkedinMaxHashtags {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "hashtags",
+			Message:  fmt.Sprintf("%d hashtags, kept the first %d", len(tags), linkedinMaxHashtags),
+		})
+		tags = tags[:linkedinMaxHashtags]
+	}
+
+	post := strings.TrimSpace(body)
+	if len(tags) > 0 {
+		line := make([]string, len(tags))
+		for i, tag := range tags {
+			line[i] = "#" + tag
+		}
+		post += "\n\n" + strings.Join(line, " ")
+	}
+	return post, tags, diags
+}
+
+// isHashtagLine reports whether a paragraph contains only hashtags.
+func isHashtagLine(p string) bool {
+	fields := strings.Fields(p)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "#") || len(f) == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// linkedinProblems returns the constraints a post violates that cannot be
+// fixed without rewriting it.
+func linkedinProblems(post string, tags []string) []string {
+	var problems []string
+	if n := utf8.RuneCountInString(post); n > linkedinMaxLength {
+		problems = append(problems, fmt.Sprintf("The post is %d characters; it must be at most %d.", n, linkedinMaxLength))
+	}
+	if len(tags) < linkedinMinHashtags {
+		problems = append(problems, fmt.Sprintf("The post has %d hashtags; it needs %d-%d relevant hashtags on a final line.", len(tags), linkedinMinHashtags, linkedinMaxHashtags))
+	}
+	return problems
+}
+
+// linkedinPreview reports an opening paragraph that does not fit before
+// LinkedIn's "see more" cutoff.
+func linkedinPreview(post string) []Diagnostic {
+	hook, _, _ := strings.Cut(post, "\n\n")
+	cut := linkedinPreviewCut(post)
+	if utf8.RuneCountInString(hook) <= cut {
+		return nil
+	}
+
+	preview := []rune(post)[:cut]
+	tail := strings.ReplaceAll(string(preview[max(0, len(preview)-40):]), "\n", " ")
+	return []Diagnostic{{
+		Severity: SeverityWarning,
+		Location: "preview",
+		Message: fmt.Sprintf("opening paragraph does not fit in the preview, which shows %d characters or %d lines; it cuts off at \"...%s\"",
+			linkedinPreviewLength, linkedinPreviewLines, tail),
+	}}
+}
+
+// linkedinPreviewCut returns the number of characters of post shown before
+// the "see more" cutoff: up to linkedinPreviewLength characters, ending
+// early after linkedinPreviewLines lines.
+func linkedinPreviewCut(post string) int {
+	lines := 1
+	for i, r := range []rune(post) {
+		if i == linkedinPreviewLength {
+			return i
+		}
+		if r == '\n' {
+			if lines++; lines > linkedinPreviewLines {
+				return i
+			}
+		}
+	}
+	return utf8.RuneCountInString(post)
+}
+
+// unicodeBold maps ASCII letters and digits to Mathematical Sans-Serif Bold.
+func unicodeBold(s string) string {
+	return mapASCII(s, 0x1D5D4, 0x1D5EE, 0x1D7EC)
+}
+
+// unicodeItalic maps ASCII letters to Mathematical Sans-Serif Italic.
+func unicodeItalic(s string) string {
+	return mapASCII(s, 0x1D608, 0x1D622, '0')
+}
+
+func mapASCII(s string, upper, lower, digit rune) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			b.WriteRune(upper + r - 'A')
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(lower + r - 'a')
+		case r >= '0' && r <= '9':
+			b.WriteRune(digit + r - '0')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
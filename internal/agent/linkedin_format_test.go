@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinkedInPlainText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		unicode bool
+		want    string
+	}{
+		{"adjacent star italics", "*one* *two*", true, "𝘰𝘯𝘦 𝘵𝘸𝘰"},
+		{"adjacent underscore italics", "_one_ _two_", true, "𝘰𝘯𝘦 𝘵𝘸𝘰"},
+		{"italics dropped", "*one* and _two_", false, "one and two"},
+		{"italic before punctuation", "really *big*.", false, "really big."},
+		{"snake case is not italic", "set max_retry_count to 3", false, "set max_retry_count to 3"},
+		{"multiplication is not italic", "2*3*4", false, "2*3*4"},
+		{"bold", "**Bold** text", true, "𝗕𝗼𝗹𝗱 text"},
+		{"bold and italic", "**bold** *italic*", false, "bold italic"},
+		{"heading", "## Key Points", true, "𝗞𝗲𝘆 𝗣𝗼𝗶𝗻𝘁𝘀"},
+		{"bullet", "- first\n- second", false, "• first\n• second"},
+		{"link", "[docs](https://example.com)", false, "docs (https://example.com)"},
+		{"code", "run `go test`", false, "run go test"},
+		{"fence and rule dropped", "```\ncode\n```\n\n---\n\nafter", false, "code\n\nafter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := linkedinPlainText(tt.text, tt.unicode); got != tt.want {
+				t.Errorf("linkedinPlainText(%q, %v) = %q, want %q", tt.text, tt.unicode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkedInPreview(t *testing.T) {
+	tests := []struct {
+		name string
+		post string
+		warn bool
+	}{
+		{"short hook", "A short hook.\n\nThe rest of the post.", false},
+		{"long first line", strings.Repeat("word ", 50) + "\n\nRest.", true},
+		{"hook over three short lines", "One.\nTwo.\nThree.\nFour.\n\nRest.", true},
+		{"hook of three short lines", "One.\nTwo.\nThree.\n\nRest.", false},
+		{"long post with short hook", "Hook.\n\n" + strings.Repeat("word ", 100), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := linkedinPreview(tt.post)
+			if got := len(diags) > 0; got != tt.warn {
+				t.Errorf("linkedinPreview(%q) = %v, want warning %v", tt.post, diags, tt.warn)
+			}
+		})
+	}
+}
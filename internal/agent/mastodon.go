@@ -0,0 +1,95 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/thread"
+)
+
+const mastodonSystemPrompt = `You are a Mastodon content creator writing threads for the fediverse.
+
+Your task is to transform a conversation into a Mastodon thread that:
+
+1. Opens with a post that clearly says what the thread is about
+2. Keeps each post under %d characters, including the 1/, 2/ numbering and any content warning; every link counts as 23 characters
+3. Uses a sincere, community-minded tone; avoid marketing language and engagement bait
+4. Breaks the key insights into one idea per post
+5. Ends with a summary or a question that invites replies
+6. Puts 3-5 hashtags at the end of the first and last posts, since hashtags are how posts are found on Mastodon
+
+Write hashtags in CamelCase (#OpenSource, not #opensource) so screen readers can read them. Mention accounts by their full handle, e.g. @user@instance.social, so they resolve from other servers.
+
+Suggest a content warning only when the topic calls for one (for example politics, health, violence or spoilers); otherwise leave it empty.
+
+Target: 3-8 posts in the thread.`
+
+// mastodonLengthInstruction gives the instance's post length to a spec
+// prompt, which is written for the default limit.
+const mastodonLengthInstruction = `
+
+This instance allows %d characters per post: keep each post under %d characters, including the 1/, 2/ numbering and any content warning.`
+
+const mastodonUserPrompt = `Transform this conversation into a Mastodon thread:
+
+%s
+
+Create a thread that shares the key insights with the fediverse in a way people will want to boost and reply to.`
+
+// MastodonAgent creates Mastodon threads.
+type MastodonAgent struct {
+	BaseAgent
+	network        thread.Network
+	contentWarning string
+}
+
+// NewMastodonAgent creates a new Mastodon thread agent for an instance whose
+// posts are limited to maxLength characters (0 = thread.MastodonMaxLength).
+// contentWarning, when set, is applied to every post; otherwise the model's
+// suggested content warning, if any, is used.
+func NewMastodonAgent(client *llm.Client, maxLength int, contentWarning string) *MastodonAgent {
+	return &MastodonAgent{
+		BaseAgent: BaseAgent{
+			name:       "mastodon",
+			outputFile: "mastodon.md",
+			client:     client,
+		},
+		network:        thread.Mastodon(maxLength),
+		contentWarning: contentWarning,
+	}
+}
+
+// Generate creates a Mastodon thread from the conversation.
+func (a *MastodonAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a Mastodon thread as structured output, applies the
+// content warning, splits posts over the instance's limit, checks handles,
+// and returns the thread as Markdown plus a mastodon.json artifact.
+func (a *MastodonAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(mastodonUserPrompt, conv.ToPrompt())
+	system := a.mastodonSystemPrompt()
+
+	var structured socialStructured
+	if err := a.client.GenerateJSON(ctx, system, prompt, socialSchema("mastodon", true), &structured); err != nil {
+		return nil, err
+	}
+	cw := contentWarning(a.contentWarning, structured.ContentWarning)
+	return socialOutput(a.network, structured.threadPosts(cw))
+}
+
+// mastodonSystemPrompt returns the system prompt for the instance's post
+// length, appending it to a spec prompt.
+func (a *MastodonAgent) mastodonSystemPrompt() string {
+	if a.specPrompt != "" {
+		return a.specPrompt + fmt.Sprintf(mastodonLengthInstruction, a.network.MaxLength, a.network.MaxLength) + a.brandPrompt
+	}
+	return fmt.Sprintf(mastodonSystemPrompt, a.network.MaxLength) + a.brandPrompt
+}
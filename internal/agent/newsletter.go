@@ -0,0 +1,205 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const newsletterSystemPrompt = `You are an email newsletter editor writing a weekly newsletter for subscribers.
+
+Your task is to transform a conversation into a newsletter issue that:
+
+1. Offers 3-5 alternative subject lines, each under 60 characters, specific rather than clickbait
+2. Has a preheader of 40-130 characters that complements rather than repeats the subject
+3. Opens with a short, personal introduction
+4. Organizes the content into 2-5 scannable sections with clear headings
+5. Keeps paragraphs short - readers skim email on their phones
+6. Ends with a single clear call to action and a friendly sign-off
+
+Write section bodies in simple Markdown: paragraphs, bullet lists, **bold**, *italics* and links. Do not use headings, images, tables or HTML inside sections.
+
+Target length: 400-800 words.`
+
+const newsletterUserPrompt = `Transform this conversation into a newsletter issue:
+
+%s
+
+Create an issue that gives subscribers the key insights from this conversation in a format they can read in a few minutes.`
+
+// Newsletter constraints checked by the newsletter agent.
+const (
+	newsletterSubjectLength   = 60  // Characters most clients show before truncating
+	newsletterPreheaderLength = 130 // Characters most clients show in the inbox
+)
+
+// NewsletterAgent creates email newsletters.
+type NewsletterAgent struct {
+	BaseAgent
+}
+
+// NewNewsletterAgent creates a new newsletter agent.
+func NewNewsletterAgent(client *llm.Client) *NewsletterAgent {
+	return &NewsletterAgent{
+		BaseAgent: BaseAgent{
+			name:       "newsletter",
+			outputFile: "newsletter.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates a newsletter issue from the conversation.
+func (a *NewsletterAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a newsletter issue as structured output and
+// returns it as Markdown, plus newsletter.html with inlined CSS and
+// newsletter.txt as the plain-text alternative for a multipart email.
+func (a *NewsletterAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(newsletterUserPrompt, conv.ToPrompt())
+
+	var issue newsletterIssue
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(newsletterSystemPrompt), prompt, newsletterSchema, &issue); err != nil {
+		return nil, err
+	}
+	if len(issue.SubjectLines) == 0 {
+		return nil, fmt.Errorf("model returned no subject lines")
+	}
+	if len(issue.Sections) == 0 {
+		return nil, fmt.Errorf("model returned no sections")
+	}
+
+	html, err := issue.HTML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render newsletter HTML: %w", err)
+	}
+
+	return &Output{
+		Content: issue.Markdown(),
+		Artifacts: []Artifact{
+			{File: "newsletter.html", Content: html},
+			{File: "newsletter.txt", Content: issue.PlainText()},
+		},
+		Diagnostics: issue.check(),
+	}, nil
+}
+
+// newsletterSchema is the structured output requested from the model.
+var newsletterSchema = llm.Schema{
+	Name:        "newsletter_issue",
+	Description: "Record the finished newsletter issue.",
+	Properties: map[string]any{
+		"subject_lines": map[string]any{
+			"type":        "array",
+			"description": "3-5 alternative subject lines, best first",
+			"items":       map[string]any{"type": "string"},
+		},
+		"preheader": map[string]any{"type": "string", "description": "Inbox preview text shown after the subject"},
+		"title":     map[string]any{"type": "string", "description": "Headline at the top of the email"},
+		"intro":     map[string]any{"type": "string", "description": "Opening paragraph(s) in Markdown"},
+		"sections": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"heading": map[string]any{"type": "string"},
+					"body":    map[string]any{"type": "string", "description": "Section content in simple Markdown, without headings"},
+				},
+				"required": []string{"heading", "body"},
+			},
+		},
+		"cta": map[string]any{
+			"type":        "object",
+			"description": "The single call to action",
+			"properties": map[string]any{
+				"text": map[string]any{"type": "string", "description": "Button label"},
+				"url":  map[string]any{"type": "string", "description": "Link target, or empty if unknown"},
+			},
+			"required": []string{"text"},
+		},
+		"sign_off": map[string]any{"type": "string", "description": "Closing line, e.g. \"Until next week,\""},
+	},
+	Required: []string{"subject_lines", "preheader", "title", "intro", "sections", "cta", "sign_off"},
+}
+
+// newsletterIssue is the model's structured newsletter.
+type newsletterIssue struct {
+	SubjectLines []string            `json:"subject_lines"`
+	Preheader    string              `json:"preheader"`
+	Title        string              `json:"title"`
+	Intro        string              `json:"intro"`
+	Sections     []newsletterSection `json:"sections"`
+	CTA          newsletterCTA       `json:"cta"`
+	SignOff      string              `json:"sign_off"`
+}
+
+type newsletterSection struct {
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+}
+
+type newsletterCTA struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// check reports subject lines and a preheader that inboxes will truncate.
+func (n newsletterIssue) check() []Diagnostic {
+	var diags []Diagnostic
+	for i, subject := range n.SubjectLines {
+		if length := len([]rune(subject)); length > newsletterSubjectLength {
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				Location: fmt.Sprintf("subject %d", i+1),
+				Message:  fmt.Sprintf("%d characters, most inboxes truncate after %d", length, newsletterSubjectLength),
+			})
+		}
+	}
+	if length := len([]rune(n.Preheader)); length > newsletterPreheaderLength {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "preheader",
+			Message:  fmt.Sprintf("%d characters, most inboxes show about %d", length, newsletterPreheaderLength),
+		})
+	}
+	if n.CTA.URL == "" {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityInfo,
+			Location: "cta",
+			Message:  "no link for the call to action; add one before sending",
+		})
+	}
+	return diags
+}
+
+// Markdown renders the issue for review, with the subject line options and
+// preheader above the body.
+func (n newsletterIssue) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", n.Title)
+	b.WriteString("**Subject lines:**\n\n")
+	for i, subject := range n.SubjectLines {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, subject)
+	}
+	fmt.Fprintf(&b, "\n**Preheader:** %s\n\n---\n\n", n.Preheader)
+	fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(n.Intro))
+	for _, s := range n.Sections {
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", s.Heading, strings.TrimSpace(s.Body))
+	}
+	if n.CTA.URL != "" {
+		fmt.Fprintf(&b, "**[%s](%s)**\n\n", n.CTA.Text, n.CTA.URL)
+	} else {
+		fmt.Fprintf(&b, "**%s**\n\n", n.CTA.Text)
+	}
+	fmt.Fprintf(&b, "%s\n", n.SignOff)
+	return b.String()
+}
@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+)
+
+// emailFont is the font stack used throughout the email.
+const emailFont = `-apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif`
+
+// emailStyles are the inline styles added to each element rendered from
+// Markdown. Many email clients drop <style> blocks, so every element
+// carries its own.
+var emailStyles = map[string]string{
+	"p":          "margin:0 0 16px;font-size:16px;line-height:1.6;color:#27272a;",
+	"ul":         "margin:0 0 16px;padding-left:24px;",
+	"ol":         "margin:0 0 16px;padding-left:24px;",
+	"li":         "margin:0 0 8px;font-size:16px;line-height:1.6;color:#27272a;",
+	"a":          "color:#2563eb;text-decoration:underline;",
+	"strong":     "font-weight:bold;",
+	"em":         "font-style:italic;",
+	"code":       "font-family:Menlo,Consolas,monospace;font-size:14px;background-color:#f4f4f5;padding:2px 4px;",
+	"pre":        "margin:0 0 16px;padding:12px;background-color:#f4f4f5;font-size:14px;white-space:pre-wrap;",
+	"blockquote": "margin:0 0 16px;padding-left:16px;border-left:4px solid #e4e4e7;color:#52525b;",
+	"h1":         "margin:0 0 12px;font-size:18px;color:#18181b;",
+	"h2":         "margin:0 0 12px;font-size:18px;color:#18181b;",
+	"h3":         "margin:0 0 12px;font-size:18px;color:#18181b;",
+	"h4":         "margin:0 0 12px;font-size:16px;color:#18181b;",
+}
+
+// emailTag matches an opening tag that gets an inline style.
+var emailTag = regexp.MustCompile(`<(p|ul|ol|li|a|strong|em|code|pre|blockquote|h[1-4])(\s[^>]*)?>`)
+
+// emailHTML converts Markdown to HTML with inline styles. Raw HTML in the
+// Markdown is omitted.
+func emailHTML(markdown string) (template.HTML, error) {
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(markdown), &buf); err != nil {
+		return "", err
+	}
+	html := emailTag.ReplaceAllStringFunc(buf.String(), func(tag string) string {
+		m := emailTag.FindStringSubmatch(tag)
+		return fmt.Sprintf(`<%s%s style="%s">`, m[1], m[2], emailStyles[m[1]])
+	})
+	return template.HTML(html), nil
+}
+
+// emailPage is the data for emailTemplate.
+type emailPage struct {
+	Subject   string
+	Preheader string
+	Title     string
+	Font      template.CSS
+	Intro     template.HTML
+	Sections  []emailSection
+	CTA       newsletterCTA
+	SignOff   string
+}
+
+type emailSection struct {
+	Heading string
+	Body    template.HTML
+}
+
+// emailTemplate lays the newsletter out in a single 600px table column,
+// which renders consistently across email clients. The preheader is hidden
+// in the body so inboxes show it as preview text.
+var emailTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="x-apple-disable-message-reformatting">
+<title>{{.Subject}}</title>
+</head>
+<body style="margin:0;padding:0;background-color:#f4f4f5;">
+<div style="display:none;max-height:0;overflow:hidden;mso-hide:all;">{{.Preheader}}</div>
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="background-color:#f4f4f5;">
+<tr>
+<td align="center" style="padding:24px 12px;">
+<table role="presentation" width="600" cellpadding="0" cellspacing="0" border="0" style="width:100%;max-width:600px;background-color:#ffffff;border-radius:8px;">
+<tr>
+<td style="padding:32px 32px 8px;font-family:{{.Font}};">
+<h1 style="margin:0 0 16px;font-size:26px;line-height:1.3;color:#18181b;">{{.Title}}</h1>
+{{.Intro}}</td>
+</tr>
+{{range .Sections}}<tr>
+<td style="padding:8px 32px;font-family:{{$.Font}};">
+<h2 style="margin:0 0 12px;font-size:20px;line-height:1.3;color:#18181b;">{{.Heading}}</h2>
+{{.Body}}</td>
+</tr>
+{{end}}<tr>
+<td align="center" style="padding:16px 32px;font-family:{{.Font}};">
+{{if .CTA.URL}}<a href="{{.CTA.URL}}" style="display:inline-block;padding:12px 24px;background-color:#2563eb;border-radius:6px;color:#ffffff;font-size:16px;font-weight:bold;text-decoration:none;">{{.CTA.Text}}</a>{{else}}<p style="margin:0;font-size:16px;font-weight:bold;color:#18181b;">{{.CTA.Text}}</p>{{end}}
+</td>
+</tr>
+<tr>
+<td style="padding:16px 32px 32px;font-family:{{.Font}};">
+<p style="margin:0;font-size:16px;line-height:1.6;color:#27272a;">{{.SignOff}}</p>
+</td>
+</tr>
+</table>
+</td>
+</tr>
+</table>
+</body>
+</html>
+`))
+
+// HTML renders the issue as a standalone email with inlined CSS, using the
+// first subject line as the title.
+func (n newsletterIssue) HTML() (string, error) {
+	page := emailPage{
+		Subject:   n.SubjectLines[0],
+		Preheader: n.Preheader,
+		Title:     n.Title,
+		Font:      emailFont,
+		CTA:       n.CTA,
+		SignOff:   n.SignOff,
+	}
+	var err error
+	if page.Intro, err = emailHTML(n.Intro); err != nil {
+		return "", err
+	}
+	for _, s := range n.Sections {
+		body, err := emailHTML(s.Body)
+		if err != nil {
+			return "", err
+		}
+		page.Sections = append(page.Sections, emailSection{Heading: s.Heading, Body: body})
+	}
+
+	var buf bytes.Buffer
+	if err := emailTemplate.Execute(&buf, page); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// PlainText renders the issue as the text/plain alternative of a multipart
+// email: Markdown is flattened, headings are underlined and links are
+// written out in parentheses.
+func (n newsletterIssue) PlainText() string {
+	plain := func(markdown string) string {
+		text, _ := linkedinPlainText(strings.TrimSpace(markdown), false)
+		return collapseBlankLines(text)
+	}
+	underline := func(heading string, char string) string {
+		return heading + "\n" + strings.Repeat(char, len([]rune(heading)))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n%s\n\n", underline(n.Title, "="), plain(n.Intro))
+	for _, s := range n.Sections {
+		fmt.Fprintf(&b, "%s\n\n%s\n\n", underline(s.Heading, "-"), plain(s.Body))
+	}
+	if n.CTA.URL != "" {
+		fmt.Fprintf(&b, "%s: %s\n\n", n.CTA.Text, n.CTA.URL)
+	} else {
+		fmt.Fprintf(&b, "%s\n\n", n.CTA.Text)
+	}
+	fmt.Fprintf(&b, "%s\n", n.SignOff)
+	return b.String()
+}
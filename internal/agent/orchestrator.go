@@ -3,8 +3,11 @@ package agent
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 
+	"github.com/agentplexus/agent-team-content/internal/brand"
 	"github.com/agentplexus/agent-team-content/internal/conversation"
 	"github.com/agentplexus/agent-team-content/internal/llm"
 )
@@ -14,35 +17,81 @@ type Orchestrator struct {
 	client  *llm.Client
 	agents  []Agent
 	options Options
+	brand   *brand.Kit
+	sem     chan struct{}
 }
 
 // NewOrchestrator creates a new orchestrator with the specified agents.
-func NewOrchestrator(client *llm.Client, opts Options) *Orchestrator {
+func NewOrchestrator(client *llm.Client, opts Options) (*Orchestrator, error) {
+	kit, err := opts.brandKit()
+	if err != nil {
+		return nil, err
+	}
+	marpTheme, revealTheme, err := opts.themes(kit)
+	if err != nil {
+		return nil, err
+	}
+
 	agents := []Agent{
 		NewBlogAgent(client),
-		NewDevToAgent(client),
-		NewLinkedInAgent(client),
-		NewTwitterAgent(client),
-		NewMarpAgent(client, opts.MarpTheme),
-		NewRevealJSAgent(client),
+		NewSummaryAgent(client),
+		NewNewsletterAgent(client),
+		NewDevToAgent(client, opts.DevToCanonicalURL, opts.DevToSeries),
+		NewDocsAgent(client),
+		NewFAQAgent(client),
+		NewSEOAgent(client, opts.SEOArticle),
+		NewVisualsAgent(client),
+		NewDiagramAgent(client),
+		NewLinkedInAgent(client, opts.LinkedInUnicode),
+		NewTwitterAgent(client, opts.TwitterRepair),
+		NewBlueskyAgent(client),
+		NewMastodonAgent(client, opts.MastodonMaxLength, opts.MastodonContentWarning),
+		NewHackerNewsAgent(client),
+		NewRedditAgent(client, opts.RedditSubreddit, opts.RedditStyle),
+		NewMarpAgent(client, marpTheme, opts.MarpRepair),
+		NewRevealJSAgent(client, revealTheme, opts.RevealTransition, opts.RevealSlideNumber, opts.RevealPlugins),
+		NewScriptAgent(client, opts.ScriptWPM),
+		NewQuizAgent(client),
 	}
 
-	return &Orchestrator{
-		client:  client,
-		agents:  agents,
-		options: opts,
-	}
+	return newOrchestrator(client, agents, opts, kit)
 }
 
 // NewOrchestratorWithAgents creates an orchestrator with specific agents.
 func NewOrchestratorWithAgents(client *llm.Client, agentNames []string, opts Options) (*Orchestrator, error) {
+	kit, err := opts.brandKit()
+	if err != nil {
+		return nil, err
+	}
+	marpTheme, revealTheme, err := opts.themes(kit)
+	if err != nil {
+		return nil, err
+	}
+
 	agentMap := map[string]func() Agent{
-		"blog":     func() Agent { return NewBlogAgent(client) },
-		"devto":    func() Agent { return NewDevToAgent(client) },
-		"linkedin": func() Agent { return NewLinkedInAgent(client) },
-		"twitter":  func() Agent { return NewTwitterAgent(client) },
-		"marp":     func() Agent { return NewMarpAgent(client, opts.MarpTheme) },
-		"revealjs": func() Agent { return NewRevealJSAgent(client) },
+		"blog":       func() Agent { return NewBlogAgent(client) },
+		"summary":    func() Agent { return NewSummaryAgent(client) },
+		"newsletter": func() Agent { return NewNewsletterAgent(client) },
+		"devto":      func() Agent { return NewDevToAgent(client, opts.DevToCanonicalURL, opts.DevToSeries) },
+		"docs":       func() Agent { return NewDocsAgent(client) },
+		"faq":        func() Agent { return NewFAQAgent(client) },
+		"seo":        func() Agent { return NewSEOAgent(client, opts.SEOArticle) },
+		"visuals":    func() Agent { return NewVisualsAgent(client) },
+		"diagram":    func() Agent { return NewDiagramAgent(client) },
+		"linkedin":   func() Agent { return NewLinkedInAgent(client, opts.LinkedInUnicode) },
+		"twitter":    func() Agent { return NewTwitterAgent(client, opts.TwitterRepair) },
+		"bluesky":    func() Agent { return NewBlueskyAgent(client) },
+		"mastodon": func() Agent {
+			return NewMastodonAgent(client, opts.MastodonMaxLength, opts.MastodonContentWarning)
+		},
+		"hackernews": func() Agent { return NewHackerNewsAgent(client) },
+		"reddit":     func() Agent { return NewRedditAgent(client, opts.RedditSubreddit, opts.RedditStyle) },
+		"marp":       func() Agent { return NewMarpAgent(client, marpTheme, opts.MarpRepair) },
+		"revealjs": func() Agent {
+			return NewRevealJSAgent(client, revealTheme, opts.RevealTransition, opts.RevealSlideNumber, opts.RevealPlugins)
+		},
+		"script": func() Agent { return NewScriptAgent(client, opts.ScriptWPM) },
+		"quiz":   func() Agent { return NewQuizAgent(client) },
 	}
 
 	var agents []Agent
@@ -54,36 +103,113 @@ func NewOrchestratorWithAgents(client *llm.Client, agentNames []string, opts Opt
 		agents = append(agents, createFn())
 	}
 
-	return &Orchestrator{
+	return newOrchestrator(client, agents, opts, kit)
+}
+
+// newOrchestrator builds an orchestrator, applying spec prompt overrides and
+// the brand kit, and setting up the concurrency limit shared by every
+// Generate call made on it.
+func newOrchestrator(client *llm.Client, agents []Agent, opts Options, kit *brand.Kit) (*Orchestrator, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if err := applySpecs(agents, opts.SpecsDir); err != nil {
+		return nil, err
+	}
+	if kit != nil {
+		for _, a := range agents {
+			if b, ok := a.(interface{ setBrandPrompt(string) }); ok {
+				b.setBrandPrompt(kit.Prompt())
+			}
+		}
+	}
+
+	o := &Orchestrator{
 		client:  client,
 		agents:  agents,
 		options: opts,
-	}, nil
+		brand:   kit,
+	}
+	if opts.MaxConcurrent > 0 {
+		o.sem = make(chan struct{}, opts.MaxConcurrent)
+	}
+	return o, nil
 }
 
 // Generate runs all agents concurrently and collects results.
 func (o *Orchestrator) Generate(ctx context.Context, conv *conversation.Conversation) []Result {
+	return o.GenerateWithProgress(ctx, conv, nil)
+}
+
+// GenerateWithProgress runs all agents concurrently and collects results,
+// calling onResult as each agent finishes. Calls to onResult are never
+// concurrent. With EmbedDiagrams, agents that embed diagrams wait for the
+// diagram agent before finishing. Without SEOArticle, the seo agent waits
+// for the article from SEOArticleSource and describes it instead of the
+// conversation.
+func (o *Orchestrator) GenerateWithProgress(ctx context.Context, conv *conversation.Conversation, onResult func(Result)) []Result {
 	var (
 		results []Result
 		mu      sync.Mutex
 		wg      sync.WaitGroup
+
+		diagrams      []Diagram
+		diagramsReady chan struct{} // closed once diagrams is set
+
+		article       string
+		articleSource string
+		articleReady  chan struct{} // closed once article is set
 	)
+	if o.options.EmbedDiagrams && slices.Contains(o.Agents(), "diagram") {
+		diagramsReady = make(chan struct{})
+	}
+	if o.options.SEOArticle == "" {
+		if articleSource = SEOArticleSource(o.Agents()); articleSource != "" {
+			articleReady = make(chan struct{})
+		}
+	}
 
 	for _, agent := range o.agents {
 		wg.Add(1)
 		go func(a Agent) {
 			defer wg.Done()
 
-			content, err := a.Generate(ctx, conv)
-			result := Result{
-				AgentName:  a.Name(),
-				OutputFile: a.OutputFile(),
-				Content:    content,
-				Error:      err,
+			var diags []Diagnostic
+			if seo, ok := a.(*SEOAgent); ok && articleReady != nil {
+				<-articleReady
+				if article != "" {
+					a = seo.withArticle(article)
+				} else {
+					diags = append(diags, Diagnostic{
+						Severity: SeverityWarning,
+						Message:  fmt.Sprintf("%s agent failed, so the conversation is described instead of its article", articleSource),
+					})
+				}
+			}
+
+			result := o.run(ctx, a, conv)
+			result.Diagnostics = append(result.Diagnostics, diags...)
+			if diagramsReady != nil {
+				if _, embeds := diagramEmbedders[a.Name()]; embeds {
+					<-diagramsReady
+					embedDiagrams(&result, diagrams)
+				} else if a.Name() == "diagram" {
+					diagrams = embeddableDiagrams(result)
+					close(diagramsReady)
+				}
+			}
+			if articleReady != nil && a.Name() == articleSource {
+				if result.Error == nil {
+					article = result.Content
+				}
+				close(articleReady)
 			}
 
 			mu.Lock()
 			results = append(results, result)
+			if onResult != nil {
+				onResult(result)
+			}
 			mu.Unlock()
 		}(agent)
 	}
@@ -92,7 +218,124 @@ func (o *Orchestrator) Generate(ctx context.Context, conv *conversation.Conversa
 	return results
 }
 
+// run executes a single agent, waiting for a concurrency slot if the
+// orchestrator is limited.
+func (o *Orchestrator) run(ctx context.Context, a Agent, conv *conversation.Conversation) Result {
+	result := Result{
+		AgentName:  a.Name(),
+		OutputFile: a.OutputFile(),
+	}
+
+	if o.sem != nil {
+		select {
+		case o.sem <- struct{}{}:
+			defer func() { <-o.sem }()
+		case <-ctx.Done():
+			result.Error = ctx.Err()
+			return result
+		}
+	}
+
+	if g, ok := a.(OutputGenerator); ok {
+		out, err := g.GenerateOutput(ctx, conv)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		result.Content = out.Content
+		result.Artifacts = out.Artifacts
+		result.Diagnostics = append(out.Diagnostics, o.brandDiagnostics(result.Content)...)
+		return result
+	}
+
+	result.Content, result.Error = a.Generate(ctx, conv)
+	if result.Error == nil {
+		result.Diagnostics = o.brandDiagnostics(result.Content)
+	}
+	return result
+}
+
+// brandDiagnostics checks content against the brand kit, if any.
+func (o *Orchestrator) brandDiagnostics(content string) []Diagnostic {
+	if o.brand == nil {
+		return nil
+	}
+	var diags []Diagnostic
+	for _, v := range o.brand.Check(content) {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: fmt.Sprintf("line %d", v.Line),
+			Message:  "brand: " + v.Message,
+		})
+	}
+	return diags
+}
+
 // ListAgents returns the names of all available agents.
 func ListAgents() []string {
-	return []string{"blog", "devto", "linkedin", "twitter", "marp", "revealjs"}
+	return []string{"blog", "summary", "newsletter", "devto", "docs", "faq", "seo", "visuals", "diagram", "linkedin", "twitter", "bluesky", "mastodon", "hackernews", "reddit", "marp", "revealjs", "script", "quiz"}
+}
+
+// agentDescriptions holds a one-line description of each agent.
+var agentDescriptions = map[string]string{
+	"blog":       "Creates engaging blog articles for Medium, Substack, and personal blogs",
+	"summary":    "Creates one-line, one-paragraph and one-page summaries with decisions and action items",
+	"newsletter": "Creates email newsletters with subject lines, a preheader, email HTML and plain text",
+	"devto":      "Creates technical articles for the dev.to developer community",
+	"docs":       "Turns support conversations into how-to and troubleshooting pages",
+	"faq":        "Creates FAQs in Markdown with FAQPage JSON-LD",
+	"seo":        "Creates SEO metadata with Open Graph, Twitter Card and JSON-LD fields",
+	"visuals":    "Proposes cover, illustration and diagram prompts with accessible alt text",
+	"diagram":    "Creates syntax-checked Mermaid flowcharts, sequence and class diagrams",
+	"linkedin":   "Creates professional LinkedIn posts that drive engagement",
+	"twitter":    "Creates viral Twitter/X threads",
+	"bluesky":    "Creates Bluesky threads within the 300-grapheme limit",
+	"mastodon":   "Creates Mastodon threads with instance length limits and content warnings",
+	"hackernews": "Creates Show HN titles and first comments without promotional language",
+	"reddit":     "Creates Reddit posts in the style of a chosen subreddit",
+	"marp":       "Creates Marp Markdown presentations with speaker notes",
+	"revealjs":   "Creates Reveal.js presentations with horizontal and vertical navigation",
+	"script":     "Creates podcast and video scripts with chapter timings and a YouTube description",
+	"quiz":       "Creates quiz questions with answer keys and Anki flashcards grounded in quoted passages",
+}
+
+// Describe returns a one-line description of the named agent.
+func Describe(name string) string {
+	return agentDescriptions[name]
+}
+
+// Agents returns the names of the agents this orchestrator runs.
+func (o *Orchestrator) Agents() []string {
+	names := make([]string, len(o.agents))
+	for i, a := range o.agents {
+		names[i] = a.Name()
+	}
+	return names
+}
+
+// ThemedAgents returns the names of agents whose output depends on theme files.
+func ThemedAgents() []string {
+	return []string{"marp", "revealjs"}
+}
+
+// DiagramEmbeddingAgents returns the names of agents that embed the diagram
+// agent's diagrams when EmbedDiagrams is set.
+func DiagramEmbeddingAgents() []string {
+	return slices.Sorted(maps.Keys(diagramEmbedders))
+}
+
+// SEOArticleSource returns the agent among names whose article the seo agent
+// describes when they run together and no article file is given: blog, or
+// devto without blog. It returns "" if seo is not among names or neither
+// article agent is.
+func SEOArticleSource(names []string) string {
+	if !slices.Contains(names, "seo") {
+		return ""
+	}
+	for _, name := range []string{"blog", "devto"} {
+		if slices.Contains(names, name) {
+			return name
+		}
+	}
+	return ""
 }
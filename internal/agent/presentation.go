@@ -3,11 +3,13 @@ package agent
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
+	"github.com/agentplexus/agent-team-content/internal/brand"
 	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/deck"
 	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/theme"
 )
 
 const marpSystemPrompt = `You are a presentation designer creating Marp Markdown slides.
@@ -48,12 +50,24 @@ footer: ''
 
 Target: 8-15 slides.`
 
+const marpThemeInstruction = `
+
+Use this theme in the frontmatter:
+
+theme: %s`
+
 const marpUserPrompt = `Transform this conversation into a Marp presentation:
 
 %s
 
 Create a clear, well-organized presentation that communicates the key points effectively.`
 
+const marpFixupPrompt = `These slides from a Marp presentation have problems:
+
+%s
+
+Rewrite each listed slide to fix its problems. Split crowded content into fewer, shorter bullets (at most %d bullets and %d lines per slide) and add speaker notes in an HTML comment (<!-- ... -->) where they are missing. Keep each slide's heading and meaning. Do not add slide separators (---).`
+
 const revealjsSystemPrompt = `You are a presentation designer creating Reveal.js Markdown slides.
 
 Your task is to transform a conversation into a Reveal.js presentation that:
@@ -100,24 +114,26 @@ Create a clear, well-organized presentation that communicates the key points eff
 // MarpAgent creates Marp presentations.
 type MarpAgent struct {
 	BaseAgent
-	theme string
+	theme  string
+	repair string
 }
 
-// NewMarpAgent creates a new Marp presentation agent.
-func NewMarpAgent(client *llm.Client, themePath string) *MarpAgent {
-	theme := "default"
-	if themePath != "" {
-		// Read custom theme or use the path as theme name
-		if _, err := os.Stat(themePath); err == nil {
-			// File exists, embed it
-			themeContent, err := os.ReadFile(themePath)
-			if err == nil {
-				theme = fmt.Sprintf("custom\nstyle: |\n%s", indentCSS(string(themeContent)))
-			}
-		} else {
-			// Use as theme name
-			theme = themePath
-		}
+// NewMarpAgent creates a new Marp presentation agent using theme t, or
+// the default theme if t is nil. A theme loaded from a file is embedded in
+// the frontmatter with a style directive. repair selects how deck problems
+// are fixed: RepairLocal makes structural fixes only, RepairLLM also asks
+// the model to rewrite overflowing slides and slides without speaker notes.
+func NewMarpAgent(client *llm.Client, t *theme.Theme, repair string) *MarpAgent {
+	name := "default"
+	switch {
+	case t == nil:
+	case t.Builtin():
+		name = t.Name
+	default:
+		name = fmt.Sprintf("%s\nstyle: |\n%s", t.Name, indentCSS(t.CSS))
+	}
+	if repair == "" {
+		repair = RepairLocal
 	}
 
 	return &MarpAgent{
@@ -126,37 +142,264 @@ func NewMarpAgent(client *llm.Client, themePath string) *MarpAgent {
 			outputFile: "marp.md",
 			client:     client,
 		},
-		theme: theme,
+		theme:  name,
+		repair: repair,
 	}
 }
 
 // Generate creates a Marp presentation from the conversation.
 func (a *MarpAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
-	systemPrompt := fmt.Sprintf(marpSystemPrompt, a.theme)
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a Marp presentation, parses it into slides,
+// repairs its frontmatter and empty slides, and reports per-slide problems
+// such as missing speaker notes and overflowing content.
+func (a *MarpAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
 	prompt := formatPrompt(marpUserPrompt, conv.ToPrompt())
-	return a.client.Generate(ctx, systemPrompt, prompt)
+	raw, err := a.client.Generate(ctx, a.marpSystemPrompt(), prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	d := deck.ParseMarp(raw)
+
+	var diags []Diagnostic
+	for _, change := range d.Repair(a.theme) {
+		diags = append(diags, deckDiagnostic(SeverityWarning, change))
+	}
+
+	limits := deck.LimitsForTheme(d.Theme())
+	if a.repair == RepairLLM {
+		diags = a.rewriteSlides(ctx, d, limits, diags)
+	}
+
+	for _, issue := range d.Check(limits) {
+		severity := SeverityWarning
+		if issue.Kind == deck.IssueFrontmatter {
+			severity = SeverityError
+		}
+		diags = append(diags, deckDiagnostic(severity, issue))
+	}
+
+	return &Output{Content: d.Render(), Diagnostics: diags}, nil
+}
+
+func (a *MarpAgent) marpSystemPrompt() string {
+	if a.specPrompt != "" {
+		return a.specPrompt + fmt.Sprintf(marpThemeInstruction, a.theme) + a.brandPrompt
+	}
+	return fmt.Sprintf(marpSystemPrompt, a.theme) + a.brandPrompt
+}
+
+// marpFixupSchema is the structured output requested for slide rewrites.
+var marpFixupSchema = llm.Schema{
+	Name:        "rewritten_slides",
+	Description: "Record the rewritten slides.",
+	Properties: map[string]any{
+		"slides": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"number":   map[string]any{"type": "integer", "description": "Slide number being rewritten"},
+					"markdown": map[string]any{"type": "string", "description": "Full Markdown for the slide, including speaker notes"},
+				},
+				"required": []string{"number", "markdown"},
+			},
+		},
+	},
+	Required: []string{"slides"},
+}
+
+// rewriteSlides sends slides that overflow or lack speaker notes back to
+// the model. A rewrite is kept only if it fixes every problem the slide had.
+func (a *MarpAgent) rewriteSlides(ctx context.Context, d *deck.Deck, limits deck.Limits, diags []Diagnostic) []Diagnostic {
+	problems := make(map[int][]string)
+	for _, issue := range d.Check(limits) {
+		if issue.Kind == deck.IssueOverflow || issue.Kind == deck.IssueNotes {
+			problems[issue.Slide] = append(problems[issue.Slide], issue.Message)
+		}
+	}
+	if len(problems) == 0 {
+		return diags
+	}
+
+	var request strings.Builder
+	for _, s := range d.Slides {
+		if p, ok := problems[s.Number]; ok {
+			fmt.Fprintf(&request, "Slide %d (%s):\n\n%s\n\n", s.Number, strings.Join(p, "; "), s.Content)
+		}
+	}
+
+	var rewritten struct {
+		Slides []struct {
+			Number   int    `json:"number"`
+			Markdown string `json:"markdown"`
+		} `json:"slides"`
+	}
+	prompt := fmt.Sprintf(marpFixupPrompt, strings.TrimSpace(request.String()), limits.MaxBullets, limits.MaxLines)
+	if err := a.client.GenerateJSON(ctx, a.marpSystemPrompt(), prompt, marpFixupSchema, &rewritten); err != nil {
+		return append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("fix-up request failed: %v", err),
+		})
+	}
+
+	for _, r := range rewritten.Slides {
+		if _, ok := problems[r.Number]; !ok || r.Number < 1 || r.Number > len(d.Slides) {
+			continue
+		}
+		candidate := deck.NewSlide(r.Number, r.Markdown)
+		if strings.Contains("\n"+candidate.Content+"\n", "\n---\n") {
+			continue
+		}
+		if len(candidate.Check(r.Number == 1, limits)) > 0 {
+			continue
+		}
+		d.Slides[r.Number-1] = candidate
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: fmt.Sprintf("slide %d", r.Number),
+			Message:  "rewritten by the model: " + strings.Join(problems[r.Number], "; "),
+		})
+	}
+	return diags
+}
+
+// deckDiagnostic converts a deck issue into a diagnostic.
+func deckDiagnostic(severity string, issue deck.Issue) Diagnostic {
+	d := Diagnostic{Severity: severity, Message: issue.Message}
+	if issue.Slide > 0 {
+		d.Location = fmt.Sprintf("slide %d", issue.Slide)
+	}
+	return d
 }
 
 // RevealJSAgent creates Reveal.js presentations.
 type RevealJSAgent struct {
 	BaseAgent
+	config revealConfig
 }
 
-// NewRevealJSAgent creates a new Reveal.js presentation agent.
-func NewRevealJSAgent(client *llm.Client) *RevealJSAgent {
+// NewRevealJSAgent creates a new Reveal.js presentation agent using theme
+// t, or the black theme if t is nil. transition, slideNumber and plugins
+// configure reveal.js; an empty transition means the slide transition, and
+// nil plugins means DefaultRevealPlugins. Pass an empty slice to enable no
+// plugins.
+func NewRevealJSAgent(client *llm.Client, t *theme.Theme, transition string, slideNumber bool, plugins []string) *RevealJSAgent {
+	if plugins == nil {
+		plugins = DefaultRevealPlugins
+	}
+	config := revealConfig{
+		Theme:         defaultRevealTheme,
+		RevealOptions: revealOptions{Transition: defaultRevealTransition, SlideNumber: slideNumber},
+		Plugins:       plugins,
+	}
+	if t != nil {
+		config.Theme = t.Name
+		if !t.Builtin() {
+			config.Style = t.CSS
+		}
+	}
+	if transition != "" {
+		config.RevealOptions.Transition = transition
+	}
+
 	return &RevealJSAgent{
 		BaseAgent: BaseAgent{
 			name:       "revealjs",
 			outputFile: "revealjs.md",
 			client:     client,
 		},
+		config: config,
 	}
 }
 
 // Generate creates a Reveal.js presentation from the conversation.
 func (a *RevealJSAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a Reveal.js presentation and writes the configured
+// theme, transition, slide numbering and plugins as reveal-md style
+// frontmatter, replacing any frontmatter the model wrote.
+func (a *RevealJSAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
 	prompt := formatPrompt(revealjsUserPrompt, conv.ToPrompt())
-	return a.client.Generate(ctx, revealjsSystemPrompt, prompt)
+	raw, err := a.client.Generate(ctx, a.systemPrompt(revealjsSystemPrompt)+a.config.instructions(), prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	d := deck.ParseReveal(raw)
+
+	var diags []Diagnostic
+	if d.HasFrontmatter {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  "replaced model-written frontmatter with the configured reveal.js options",
+		})
+	}
+	d.HasFrontmatter = true
+	d.Frontmatter = a.config.frontmatter()
+	d.FrontmatterErr = nil
+
+	return &Output{Content: d.Render(), Diagnostics: diags}, nil
+}
+
+// themes resolves the Marp and Reveal.js theme options against the
+// built-in themes and ThemesDir, and layers the brand kit's deck styling
+// on top. A nil theme means the agent's default theme.
+func (o Options) themes(kit *brand.Kit) (marp, reveal *theme.Theme, err error) {
+	if o.MarpTheme != "" || o.RevealTheme != "" {
+		registry, err := theme.Discover(o.ThemesDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		if o.MarpTheme != "" {
+			if marp, err = registry.Resolve(theme.TargetMarp, o.MarpTheme); err != nil {
+				return nil, nil, err
+			}
+		}
+		if o.RevealTheme != "" {
+			if reveal, err = registry.Resolve(theme.TargetReveal, o.RevealTheme); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if kit != nil {
+		marp = brandTheme(kit, marp, theme.TargetMarp, "default")
+		reveal = brandTheme(kit, reveal, theme.TargetReveal, defaultRevealTheme)
+	}
+	return marp, reveal, nil
+}
+
+// brandTheme layers the brand kit's deck CSS on top of t, or on the named
+// base theme if t is nil. The result is embedded in the deck like a theme
+// file.
+func brandTheme(kit *brand.Kit, t *theme.Theme, target, base string) *theme.Theme {
+	css := kit.CSS(target)
+	if css == "" {
+		return t
+	}
+	branded := theme.Theme{Name: base, Target: target}
+	if t != nil {
+		branded = *t
+		if t.Builtin() {
+			branded.CSS = ""
+		}
+	}
+	branded.Path = kit.Path
+	branded.CSS = strings.TrimSpace(branded.CSS + "\n" + css)
+	return &branded
 }
 
 // indentCSS adds proper indentation for YAML embedding.
@@ -0,0 +1,342 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const quizSystemPrompt = `You are an instructional designer turning a technical conversation into course material.
+
+Your task is to write learning material that:
+
+1. Has 5-8 multiple-choice questions, each with 4 options, exactly one of them correct, and plausible distractors based on real misconceptions
+2. Has 3-5 short-answer questions that can be answered in one or two sentences
+3. Has 8-15 flashcards, each a single fact, term or command: a short prompt on the front, the answer on the back
+4. Tests understanding and application, not trivia about who said what
+5. Explains every answer in one or two sentences
+6. Grounds every answer in the conversation: quote the passage that supports it word for word, shortening it with "..." if needed
+
+Only ask about what the conversation establishes. If the conversation does not support an answer, leave the question out.`
+
+const quizUserPrompt = `Create quiz questions and flashcards from this conversation:
+
+%s`
+
+// quizOptions is the number of options multiple-choice questions should
+// have.
+const quizOptions = 4
+
+// QuizAgent creates quizzes and flashcards.
+type QuizAgent struct {
+	BaseAgent
+}
+
+// NewQuizAgent creates a new quiz and flashcard agent.
+func NewQuizAgent(client *llm.Client) *QuizAgent {
+	return &QuizAgent{
+		BaseAgent: BaseAgent{
+			name:       "quiz",
+			outputFile: "quiz.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates a quiz from the conversation.
+func (a *QuizAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates questions and flashcards as structured output and
+// checks that every answer is supported by a passage quoted from the
+// conversation. The quiz is written as Markdown with an answer key and as
+// quiz.json, and the supported flashcards as Anki-compatible CSV.
+func (a *QuizAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(quizUserPrompt, conv.ToPrompt())
+
+	var quiz Quiz
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(quizSystemPrompt), prompt, quizSchema, &quiz); err != nil {
+		return nil, err
+	}
+	if len(quiz.MultipleChoice)+len(quiz.ShortAnswer)+len(quiz.Flashcards) == 0 {
+		return nil, fmt.Errorf("model returned no questions or flashcards")
+	}
+	quiz.Title = firstNonEmpty(conv.Title, "Quiz")
+	quiz.MultipleChoice = nonNil(quiz.MultipleChoice)
+	quiz.ShortAnswer = nonNil(quiz.ShortAnswer)
+	quiz.Flashcards = nonNil(quiz.Flashcards)
+
+	diags := quiz.check(conv)
+
+	listing, err := json.MarshalIndent(quiz, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode quiz: %w", err)
+	}
+	csv, err := quiz.AnkiCSV()
+	if err != nil {
+		return nil, err
+	}
+	return &Output{
+		Content: quiz.Markdown(),
+		Artifacts: []Artifact{
+			{File: "quiz.json", Content: string(listing) + "\n"},
+			{File: "flashcards.csv", Content: csv},
+		},
+		Diagnostics: diags,
+	}, nil
+}
+
+// quizSourceProperty is the schema of a quoted source passage.
+var quizSourceProperty = map[string]any{
+	"type":        "string",
+	"description": "Passage from the conversation that supports the answer, quoted word for word",
+}
+
+// quizSchema is the structured output requested from the model.
+var quizSchema = llm.Schema{
+	Name:        "quiz",
+	Description: "Record the quiz questions and flashcards.",
+	Properties: map[string]any{
+		"multiple_choice": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"question":    map[string]any{"type": "string"},
+					"options":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"answer":      map[string]any{"type": "integer", "description": "Index of the correct option, from 0"},
+					"explanation": map[string]any{"type": "string"},
+					"source":      quizSourceProperty,
+				},
+				"required": []string{"question", "options", "answer", "explanation", "source"},
+			},
+		},
+		"short_answer": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"question":    map[string]any{"type": "string"},
+					"answer":      map[string]any{"type": "string"},
+					"explanation": map[string]any{"type": "string"},
+					"source":      quizSourceProperty,
+				},
+				"required": []string{"question", "answer", "explanation", "source"},
+			},
+		},
+		"flashcards": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"front":  map[string]any{"type": "string"},
+					"back":   map[string]any{"type": "string"},
+					"tags":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"source": quizSourceProperty,
+				},
+				"required": []string{"front", "back", "source"},
+			},
+		},
+	},
+	Required: []string{"multiple_choice", "short_answer", "flashcards"},
+}
+
+// Quiz is the learning material written to quiz.json.
+type Quiz struct {
+	Title          string               `json:"title"`
+	MultipleChoice []MultipleChoiceItem `json:"multiple_choice"`
+	ShortAnswer    []ShortAnswerItem    `json:"short_answer"`
+	Flashcards     []Flashcard          `json:"flashcards"`
+}
+
+// MultipleChoiceItem is a multiple-choice question.
+type MultipleChoiceItem struct {
+	Question    string   `json:"question"`
+	Options     []string `json:"options"`
+	Answer      int      `json:"answer"` // Index of the correct option
+	Explanation string   `json:"explanation"`
+	Source      string   `json:"source"`    // Supporting passage quoted from the conversation
+	Supported   bool     `json:"supported"` // Whether Source was found in the conversation and shares terms with the answer
+}
+
+// ShortAnswerItem is a short-answer question.
+type ShortAnswerItem struct {
+	Question    string `json:"question"`
+	Answer      string `json:"answer"`
+	Explanation string `json:"explanation"`
+	Source      string `json:"source"`
+	Supported   bool   `json:"supported"`
+}
+
+// Flashcard is a question-and-answer card.
+type Flashcard struct {
+	Front     string   `json:"front"`
+	Back      string   `json:"back"`
+	Tags      []string `json:"tags,omitempty"`
+	Source    string   `json:"source"`
+	Supported bool     `json:"supported"`
+}
+
+// check marks which items are supported by a passage quoted from the
+// conversation that shares terms with the answer, reporting those that are
+// not as errors, and reports malformed multiple-choice questions.
+func (q *Quiz) check(conv *conversation.Conversation) []Diagnostic {
+	var diags []Diagnostic
+	// support reports an item whose source is missing, not quoted from the
+	// conversation, or unrelated to its answer, adding note to the message.
+	support := func(location, answer, source, note string) bool {
+		var message string
+		switch {
+		case strings.TrimSpace(source) == "":
+			message = "answer has no source passage"
+		case !quotedFrom(conv, source):
+			message = fmt.Sprintf("source passage not found in the conversation, answer is unsupported: %q", source)
+		case !sharesTerms(answer, source):
+			message = fmt.Sprintf("answer %q shares no terms with its source passage, answer is unsupported", strings.TrimSpace(answer))
+		default:
+			return true
+		}
+		diags = append(diags, Diagnostic{Severity: SeverityError, Location: location, Message: message + note})
+		return false
+	}
+
+	for i := range q.MultipleChoice {
+		item := &q.MultipleChoice[i]
+		location := fmt.Sprintf("multiple choice %d", i+1)
+		var answer string
+		if item.Answer >= 0 && item.Answer < len(item.Options) {
+			answer = item.Options[item.Answer]
+		}
+		item.Supported = support(location, answer, item.Source, "")
+
+		switch {
+		case item.Answer < 0 || item.Answer >= len(item.Options):
+			diags = append(diags, Diagnostic{Severity: SeverityError, Location: location, Message: fmt.Sprintf("answer index %d is not one of the %d options", item.Answer, len(item.Options))})
+		case len(item.Options) != quizOptions:
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: fmt.Sprintf("%d options, expected %d", len(item.Options), quizOptions)})
+		}
+		seen := map[string]bool{}
+		for _, option := range item.Options {
+			key := normalizeSpace(option)
+			if seen[key] {
+				diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: fmt.Sprintf("duplicate option %q", option)})
+			}
+			seen[key] = true
+		}
+	}
+	for i := range q.ShortAnswer {
+		item := &q.ShortAnswer[i]
+		item.Supported = support(fmt.Sprintf("short answer %d", i+1), item.Answer, item.Source, "")
+	}
+	for i := range q.Flashcards {
+		card := &q.Flashcards[i]
+		card.Supported = support(fmt.Sprintf("flashcard %d", i+1), card.Back, card.Source, "; not exported")
+	}
+	return diags
+}
+
+// stopWords are common English words that do not tie an answer to a
+// passage.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true,
+	"you": true, "all": true, "can": true, "has": true, "have": true, "was": true,
+	"were": true, "its": true, "this": true, "that": true, "with": true, "from": true,
+	"they": true, "them": true, "their": true, "there": true, "then": true, "than": true,
+	"will": true, "would": true, "should": true, "could": true, "what": true, "when": true,
+	"which": true, "who": true, "how": true, "why": true, "into": true, "only": true,
+	"also": true, "more": true, "most": true, "some": true, "any": true, "each": true,
+	"been": true, "being": true, "does": true, "did": true, "use": true, "uses": true,
+	"used": true, "using": true, "about": true, "over": true, "just": true, "because": true,
+	"yes": true, "true": true, "false": true,
+}
+
+// terms returns the stemmed content words of text: words of three or more
+// letters that are not stop words, and anything containing a digit.
+func terms(text string) []string {
+	var out []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if strings.IndexFunc(word, unicode.IsDigit) < 0 && (utf8.RuneCountInString(word) < 3 || stopWords[word]) {
+			continue
+		}
+		for _, suffix := range []string{"ing", "ed", "es", "s"} {
+			if stem, ok := strings.CutSuffix(word, suffix); ok && utf8.RuneCountInString(stem) >= 3 {
+				word = stem
+				break
+			}
+		}
+		out = append(out, word)
+	}
+	return out
+}
+
+// sharesTerms reports whether an answer has a content word in common with
+// its source passage, counting words where one stem starts with the other
+// (cach, cache). An answer without content words, such as "Yes", passes.
+func sharesTerms(answer, passage string) bool {
+	answerTerms := terms(answer)
+	if len(answerTerms) == 0 {
+		return true
+	}
+	passageTerms := terms(passage)
+	for _, a := range answerTerms {
+		for _, p := range passageTerms {
+			if strings.HasPrefix(a, p) || strings.HasPrefix(p, a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quoteMarkup is formatting that a quoted passage may add or drop without
+// changing its words.
+var quoteMarkup = strings.NewReplacer("*", "", "`", "", "“", `"`, "”", `"`, "‘", "'", "’", "'")
+
+// ellipsisPattern matches an ellipsis marking omitted words in a quote.
+var ellipsisPattern = regexp.MustCompile(`\s*(?:\.\.\.|…|\[\.\.\.\])\s*`)
+
+// quotedFrom reports whether a passage is quoted from one of the
+// conversation's messages. Every part of the passage between ellipses must
+// appear in the same message, ignoring case, whitespace, emphasis and curly
+// quotes.
+func quotedFrom(conv *conversation.Conversation, passage string) bool {
+	passage = strings.Trim(strings.TrimSpace(quoteMarkup.Replace(passage)), `"'`)
+	var parts []string
+	for _, part := range ellipsisPattern.Split(passage, -1) {
+		if part = normalizeSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return false
+	}
+
+	for _, msg := range conv.Messages {
+		content := normalizeSpace(quoteMarkup.Replace(msg.Content))
+		found := true
+		for _, part := range parts {
+			if !strings.Contains(content, part) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the questions for learners, followed by an answer key
+// with explanations and the supporting passages, and the flashcards.
+func (q Quiz) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", q.Title)
+
+	if len(q.MultipleChoice) > 0 {
+		b.WriteString("\n## Multiple Choice\n")
+		for i, item := range q.MultipleChoice {
+			fmt.Fprintf(&b, "\n%d. %s\n\n", i+1, strings.TrimSpace(item.Question))
+			for j, option := range item.Options {
+				fmt.Fprintf(&b, "   - %s. %s\n", optionLetter(j), strings.TrimSpace(option))
+			}
+		}
+	}
+	if len(q.ShortAnswer) > 0 {
+		b.WriteString("\n## Short Answer\n\n")
+		for i, item := range q.ShortAnswer {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, strings.TrimSpace(item.Question))
+		}
+	}
+
+	if len(q.MultipleChoice)+len(q.ShortAnswer) > 0 {
+		b.WriteString("\n## Answer Key\n")
+		for i, item := range q.MultipleChoice {
+			answer := "?"
+			if item.Answer >= 0 && item.Answer < len(item.Options) {
+				answer = fmt.Sprintf("%s. %s", optionLetter(item.Answer), strings.TrimSpace(item.Options[item.Answer]))
+			}
+			writeAnswer(&b, fmt.Sprintf("Multiple choice %d", i+1), answer, item.Explanation, item.Source, item.Supported)
+		}
+		for i, item := range q.ShortAnswer {
+			writeAnswer(&b, fmt.Sprintf("Short answer %d", i+1), item.Answer, item.Explanation, item.Source, item.Supported)
+		}
+	}
+
+	if len(q.Flashcards) > 0 {
+		b.WriteString("\n## Flashcards\n\n| Front | Back |\n|-------|------|\n")
+		for _, card := range q.Flashcards {
+			fmt.Fprintf(&b, "| %s | %s |\n", tableCell(card.Front), tableCell(card.Back))
+		}
+	}
+	return b.String()
+}
+
+// writeAnswer writes one answer key entry, quoting its source passage and
+// flagging answers the conversation does not support.
+func writeAnswer(b *strings.Builder, label, answer, explanation, source string, supported bool) {
+	fmt.Fprintf(b, "\n**%s:** %s\n", label, strings.TrimSpace(answer))
+	if !supported {
+		b.WriteString("\n_Not supported by the conversation; review before use._\n")
+	}
+	if explanation = strings.TrimSpace(explanation); explanation != "" {
+		fmt.Fprintf(b, "\n%s\n", explanation)
+	}
+	if source = strings.TrimSpace(source); source != "" {
+		fmt.Fprintf(b, "\n> %s\n", strings.ReplaceAll(source, "\n", "\n> "))
+	}
+}
+
+// optionLetter returns the letter labeling the option at index i.
+func optionLetter(i int) string {
+	return string(rune('A' + i))
+}
+
+// AnkiCSV returns the supported flashcards as CSV with Anki's file headers,
+// so they import as Basic notes with tags. Fields are plain text.
+func (q Quiz) AnkiCSV() (string, error) {
+	var buf bytes.Buffer
+	buf.WriteString("#separator:comma\n#html:false\n#notetype:Basic\n")
+	fmt.Fprintf(&buf, "#deck:%s\n", strings.Join(strings.Fields(q.Title), " "))
+	buf.WriteString("#columns:Front,Back,Tags\n#tags column:3\n")
+
+	w := csv.NewWriter(&buf)
+	for _, card := range q.Flashcards {
+		if !card.Supported {
+			continue
+		}
+		if err := w.Write([]string{strings.TrimSpace(card.Front), strings.TrimSpace(card.Back), ankiTags(card.Tags)}); err != nil {
+			return "", fmt.Errorf("failed to write flashcards: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write flashcards: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// ankiTags joins tags with spaces, as Anki expects, replacing spaces
+// within a tag with hyphens.
+func ankiTags(tags []string) string {
+	var out []string
+	for _, tag := range tags {
+		if tag = strings.Join(strings.Fields(strings.ToLower(tag)), "-"); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,78 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+)
+
+func TestSharesTerms(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  string
+		passage string
+		want    bool
+	}{
+		{"same word", "Redis", "We put Redis in front of the database.", true},
+		{"stemmed", "Caching the results", "Cache the query results for an hour.", true},
+		{"number", "30 seconds", "The timeout is 30 seconds.", true},
+		{"unrelated", "PostgreSQL replicas", "We put Redis in front of the database.", false},
+		{"stop words only", "All of them", "Every request is retried.", true},
+		{"yes", "Yes", "Retries are enabled by default.", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sharesTerms(tt.answer, tt.passage); got != tt.want {
+				t.Errorf("sharesTerms(%q, %q) = %v, want %v", tt.answer, tt.passage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuizCheck(t *testing.T) {
+	conv := &conversation.Conversation{Messages: []conversation.Message{
+		{Role: "user", Content: "Why is the API slow?"},
+		{Role: "assistant", Content: "Every request queries the database. Cache the query results in Redis for an hour."},
+	}}
+	q := Quiz{
+		MultipleChoice: []MultipleChoiceItem{
+			{Question: "Where are results cached?", Options: []string{"Memcached", "Redis", "Disk", "CDN"}, Answer: 1, Source: "Cache the query results in Redis"},
+			{Question: "Where are results cached?", Options: []string{"Memcached", "Redis", "Disk", "CDN"}, Answer: 0, Source: "Cache the query results in Redis"},
+		},
+		ShortAnswer: []ShortAnswerItem{
+			{Question: "Why is the API slow?", Answer: "Every request queries the database", Source: "Every request queries the database."},
+			{Question: "How long are results kept?", Answer: "A day", Source: "for a day"},
+		},
+		Flashcards: []Flashcard{
+			{Front: "Cache", Back: "Redis, for an hour", Source: "in Redis for an hour"},
+			{Front: "Cache", Back: "Memcached", Source: "in Redis for an hour"},
+		},
+	}
+
+	diags := q.check(conv)
+
+	supported := []bool{
+		q.MultipleChoice[0].Supported, q.MultipleChoice[1].Supported,
+		q.ShortAnswer[0].Supported, q.ShortAnswer[1].Supported,
+		q.Flashcards[0].Supported, q.Flashcards[1].Supported,
+	}
+	want := []bool{true, false, true, false, true, false}
+	for i := range want {
+		if supported[i] != want[i] {
+			t.Errorf("item %d supported = %v, want %v (diagnostics: %v)", i, supported[i], want[i], diags)
+		}
+	}
+	if len(diags) != 3 {
+		t.Errorf("got %d diagnostics, want 3: %v", len(diags), diags)
+	}
+}
+
+func TestQuizMarkdownOptions(t *testing.T) {
+	q := Quiz{Title: "Caching", MultipleChoice: []MultipleChoiceItem{
+		{Question: "Where?", Options: []string{"Redis", "Disk"}, Answer: 0, Supported: true},
+	}}
+	if md := q.Markdown(); !strings.Contains(md, "1. Where?\n\n   - A. Redis\n   - B. Disk\n") {
+		t.Errorf("options are not a nested list:\n%s", md)
+	}
+}
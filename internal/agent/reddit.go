@@ -0,0 +1,176 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const redditSystemPrompt = `You are a longtime Reddit user sharing something with a community you take part in, not a marketer.
+
+Your task is to transform a conversation into a Reddit post for r/%s that:
+
+1. Has a specific, descriptive title of at most 300 characters; no clickbait, hype or exclamation marks
+2. %s
+3. Is useful on its own: readers should get the value without clicking any link
+4. Mentions your own project or company at most once, and says plainly that it is yours
+5. Uses Reddit Markdown: paragraphs, bullet lists, **bold**, links and fenced code blocks
+
+Reddit communities remove self-promotion and downvote marketing language. Write the way the regulars of r/%s write.
+
+Also suggest a post flair that the subreddit is likely to have, or leave it empty.
+
+Target: 200-600 words.`
+
+// redditStyleInstruction gives the subreddit and post style to a spec
+// prompt, which describes the styles in general.
+const redditStyleInstruction = `
+
+This post is for r/%s. Post style: %s.`
+
+const redditUserPrompt = `Transform this conversation into a Reddit post for r/%s:
+
+%s
+
+Create a post the community will upvote because it is genuinely useful, not because it promotes anything.`
+
+// Reddit post styles.
+const (
+	RedditDiscussion = "discussion"
+	RedditShowcase   = "showcase"
+	RedditTutorial   = "tutorial"
+	RedditQuestion   = "question"
+)
+
+// RedditStyles are the supported Reddit post styles.
+var RedditStyles = []string{RedditDiscussion, RedditShowcase, RedditTutorial, RedditQuestion}
+
+// redditStyleGuidance describes each style in the system prompt.
+var redditStyleGuidance = map[string]string{
+	RedditDiscussion: "Starts a discussion: shares what was learned, takes a position, and ends with an open question for the community",
+	RedditShowcase:   "Shows something that was built: what it does, how it works, what was hard, and what feedback you are looking for",
+	RedditTutorial:   "Teaches: a self-contained walkthrough with steps and code blocks that readers can follow",
+	RedditQuestion:   "Asks the community for advice: gives the context, what was already tried, and a specific question",
+}
+
+// DefaultSubreddit is the subreddit posts are written for when none is set.
+const DefaultSubreddit = "programming"
+
+// redditTitleLength is Reddit's title length limit.
+const redditTitleLength = 300
+
+// subredditPattern matches a valid subreddit name.
+var subredditPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_]{1,20}$`)
+
+// RedditAgent creates Reddit posts.
+type RedditAgent struct {
+	BaseAgent
+	subreddit string
+	style     string
+}
+
+// NewRedditAgent creates a new Reddit post agent for subreddit (with or
+// without "r/", default DefaultSubreddit) in style, one of RedditStyles
+// (default RedditDiscussion).
+func NewRedditAgent(client *llm.Client, subreddit, style string) *RedditAgent {
+	subreddit = normalizeSubreddit(subreddit)
+	if subreddit == "" {
+		subreddit = DefaultSubreddit
+	}
+	if style == "" {
+		style = RedditDiscussion
+	}
+
+	return &RedditAgent{
+		BaseAgent: BaseAgent{
+			name:       "reddit",
+			outputFile: "reddit.md",
+			client:     client,
+		},
+		subreddit: subreddit,
+		style:     style,
+	}
+}
+
+// redditSystemPrompt returns the system prompt for the subreddit and style,
+// appending them to a spec prompt.
+func (a *RedditAgent) redditSystemPrompt() string {
+	if a.specPrompt != "" {
+		return a.specPrompt + fmt.Sprintf(redditStyleInstruction, a.subreddit, redditStyleGuidance[a.style]) + a.brandPrompt
+	}
+	return fmt.Sprintf(redditSystemPrompt, a.subreddit, redditStyleGuidance[a.style], a.subreddit) + a.brandPrompt
+}
+
+// Generate creates a Reddit post from the conversation.
+func (a *RedditAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a Reddit post as structured output, enforces the
+// 300-character title limit with one rewrite, and warns about promotional
+// language in the body.
+func (a *RedditAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := fmt.Sprintf(redditUserPrompt, a.subreddit, conv.ToPrompt())
+	system := a.redditSystemPrompt()
+
+	var post redditPost
+	if err := a.client.GenerateJSON(ctx, system, prompt, redditSchema, &post); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(post.Body) == "" {
+		return nil, fmt.Errorf("model returned an empty post")
+	}
+
+	var diags []Diagnostic
+	post.Title, diags = a.communityTitle(ctx, system, "Reddit", post.Title, redditTitleLength, strings.TrimSpace)
+	diags = append(diags, promotionalDiagnostics("body", post.Body)...)
+
+	return &Output{Content: post.Markdown(a.subreddit, a.style), Diagnostics: diags}, nil
+}
+
+// redditSchema is the structured output requested from the model.
+var redditSchema = llm.Schema{
+	Name:        "reddit_post",
+	Description: "Record the finished Reddit post.",
+	Properties: map[string]any{
+		"title": map[string]any{"type": "string", "description": "Post title, at most 300 characters"},
+		"body":  map[string]any{"type": "string", "description": "Post body in Reddit Markdown"},
+		"flair": map[string]any{"type": "string", "description": "Suggested post flair, or empty"},
+	},
+	Required: []string{"title", "body"},
+}
+
+// redditPost is the model's structured post.
+type redditPost struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Flair string `json:"flair"`
+}
+
+// Markdown renders the post with the subreddit, style and flair above the
+// title and body.
+func (p redditPost) Markdown(subreddit, style string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", p.Title)
+	details := []string{"**Subreddit:** r/" + subreddit, "**Style:** " + style}
+	if p.Flair != "" {
+		details = append(details, "**Flair:** "+p.Flair)
+	}
+	fmt.Fprintf(&b, "%s\n\n---\n\n%s\n", strings.Join(details, "  \n"), strings.TrimSpace(p.Body))
+	return b.String()
+}
+
+// normalizeSubreddit strips a leading "r/" or "/r/" from name.
+func normalizeSubreddit(name string) string {
+	name = strings.TrimSpace(name)
+	name = strings.TrimPrefix(name, "/")
+	return strings.TrimPrefix(name, "r/")
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RevealTransitions are the slide transitions reveal.js supports.
+var RevealTransitions = []string{"none", "fade", "slide", "convex", "concave", "zoom"}
+
+// RevealPlugins are the reveal.js plugins that can be enabled.
+var RevealPlugins = []string{"notes", "highlight", "math", "search", "zoom"}
+
+// DefaultRevealPlugins are the plugins enabled when none are configured.
+var DefaultRevealPlugins = []string{"notes", "highlight"}
+
+// Reveal.js defaults used when options are unset.
+const (
+	defaultRevealTheme      = "black"
+	defaultRevealTransition = "slide"
+)
+
+// revealConfig is the reveal.js configuration written as reveal-md style
+// frontmatter at the top of revealjs.md.
+type revealConfig struct {
+	Theme         string        `yaml:"theme"`
+	Style         string        `yaml:"style,omitempty"` // Custom theme CSS when Theme is "custom"
+	RevealOptions revealOptions `yaml:"revealOptions"`
+	Plugins       []string      `yaml:"plugins,omitempty"`
+}
+
+// revealOptions are passed to Reveal.initialize.
+type revealOptions struct {
+	Transition  string `yaml:"transition"`
+	SlideNumber bool   `yaml:"slideNumber"`
+}
+
+// frontmatter returns the configuration as YAML frontmatter, without the
+// --- delimiters.
+func (c revealConfig) frontmatter() string {
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		// A struct of strings and bools always marshals.
+		panic(err)
+	}
+	return strings.TrimRight(string(out), "\n")
+}
+
+// instructions describes the configuration to the model so the slides can
+// make use of it.
+func (c revealConfig) instructions() string {
+	var b strings.Builder
+	b.WriteString("\n\nThe deck's reveal.js configuration is added separately; do not write frontmatter or configuration.\n")
+	fmt.Fprintf(&b, "\nTheme: %s. Transition: %s.", c.Theme, c.RevealOptions.Transition)
+	if c.RevealOptions.SlideNumber {
+		b.WriteString(" Slide numbers are shown.")
+	}
+	if len(c.Plugins) > 0 {
+		fmt.Fprintf(&b, "\nEnabled plugins: %s.", strings.Join(c.Plugins, ", "))
+	}
+	for _, plugin := range c.Plugins {
+		switch plugin {
+		case "highlight":
+			b.WriteString("\n- Give code blocks a language, and highlight the lines being discussed with [1|2-3] after it.")
+		case "math":
+			b.WriteString("\n- Write math as LaTeX between $ or $$ delimiters.")
+		}
+	}
+	if !slices.Contains(c.Plugins, "math") {
+		b.WriteString("\n- The math plugin is not enabled; do not use LaTeX.")
+	}
+	return b.String()
+}
@@ -0,0 +1,222 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const scriptSystemPrompt = `You are a scriptwriter for a developer-focused YouTube channel and podcast.
+
+Your task is to transform a conversation into a spoken-word episode script that:
+
+1. Opens with a cold open or hook in the first 15 seconds
+2. Is written for the ear: short sentences, contractions, no Markdown, no code read aloud character by character
+3. Alternates between a host, who guides the episode, and a guest, who brings the expertise from the conversation
+4. Is divided into 4-8 chapters, each covering one idea, with a short chapter title suitable for YouTube chapters
+5. Adds visual cues (B-roll, screen recordings, diagrams, on-screen text) where they help viewers follow along
+6. Ends with a recap and a call to action (subscribe, follow, or a link to learn more)
+
+Also write a YouTube description of 2-3 short paragraphs and up to 3 hashtags.
+
+Target runtime: 8-15 minutes.`
+
+const scriptUserPrompt = `Transform this conversation into a podcast and video episode script:
+
+%s
+
+Create a script that a host and guest can read naturally, with chapters viewers can jump between.`
+
+// DefaultScriptWPM is the speaking rate used for duration estimates when
+// none is configured. Conversational podcasts run at about 150 words per
+// minute.
+const DefaultScriptWPM = 150
+
+// youtubeMinChapter is the shortest chapter YouTube accepts, in seconds.
+const youtubeMinChapter = 10
+
+// youtubeMinChapters is the number of chapters YouTube needs to show them.
+const youtubeMinChapters = 3
+
+// youtubeDescriptionLength is the maximum length of a YouTube description.
+const youtubeDescriptionLength = 5000
+
+// ScriptAgent creates podcast and video scripts.
+type ScriptAgent struct {
+	BaseAgent
+	wpm int
+}
+
+// NewScriptAgent creates a new script agent. wpm is the speaking rate in
+// words per minute used to estimate chapter durations (0 = DefaultScriptWPM).
+func NewScriptAgent(client *llm.Client, wpm int) *ScriptAgent {
+	if wpm <= 0 {
+		wpm = DefaultScriptWPM
+	}
+
+	return &ScriptAgent{
+		BaseAgent: BaseAgent{
+			name:       "script",
+			outputFile: "script.md",
+			client:     client,
+		},
+		wpm: wpm,
+	}
+}
+
+// Generate creates an episode script from the conversation.
+func (a *ScriptAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates an episode script as structured output, estimates
+// each chapter's duration from its spoken word count, and returns the script
+// as Markdown plus youtube.txt with the description and chapter timestamps.
+func (a *ScriptAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(scriptUserPrompt, conv.ToPrompt())
+
+	var script episodeScript
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(scriptSystemPrompt), prompt, scriptSchema, &script); err != nil {
+		return nil, err
+	}
+	if len(script.Chapters) == 0 {
+		return nil, fmt.Errorf("model returned no chapters")
+	}
+	if script.Host == "" {
+		script.Host = "Host"
+	}
+	if script.Guest == "" {
+		script.Guest = "Guest"
+	}
+
+	timings := script.timings(a.wpm)
+	youtube := script.YouTube(timings)
+	return &Output{
+		Content:     script.Markdown(timings, a.wpm),
+		Artifacts:   []Artifact{{File: "youtube.txt", Content: youtube}},
+		Diagnostics: script.check(timings, youtube),
+	}, nil
+}
+
+// scriptSchema is the structured output requested from the model.
+var scriptSchema = llm.Schema{
+	Name:        "episode_script",
+	Description: "Record the finished episode script.",
+	Properties: map[string]any{
+		"title": map[string]any{"type": "string", "description": "Episode title"},
+		"host":  map[string]any{"type": "string", "description": "Host name, or \"Host\" if unknown"},
+		"guest": map[string]any{"type": "string", "description": "Guest name, or \"Guest\" if unknown"},
+		"chapters": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title": map[string]any{"type": "string", "description": "Short chapter title for YouTube chapters"},
+					"lines": map[string]any{
+						"type":        "array",
+						"description": "Spoken lines and visual cues in order",
+						"items": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"speaker": map[string]any{"type": "string", "enum": []string{"host", "guest"}},
+								"text":    map[string]any{"type": "string", "description": "What the speaker says, written for the ear"},
+								"visual":  map[string]any{"type": "string", "description": "Optional B-roll or on-screen cue shown while this line is spoken"},
+							},
+							"required": []string{"speaker", "text"},
+						},
+					},
+				},
+				"required": []string{"title", "lines"},
+			},
+		},
+		"description": map[string]any{"type": "string", "description": "YouTube description, 2-3 short plain-text paragraphs"},
+		"hashtags": map[string]any{
+			"type":        "array",
+			"description": "Up to 3 hashtags for YouTube, with #",
+			"items":       map[string]any{"type": "string"},
+		},
+	},
+	Required: []string{"title", "host", "guest", "chapters", "description"},
+}
+
+// episodeScript is the model's structured episode script.
+type episodeScript struct {
+	Title       string          `json:"title"`
+	Host        string          `json:"host"`
+	Guest       string          `json:"guest"`
+	Chapters    []scriptChapter `json:"chapters"`
+	Description string          `json:"description"`
+	Hashtags    []string        `json:"hashtags"`
+}
+
+type scriptChapter struct {
+	Title string       `json:"title"`
+	Lines []scriptLine `json:"lines"`
+}
+
+type scriptLine struct {
+	Speaker string `json:"speaker"`
+	Text    string `json:"text"`
+	Visual  string `json:"visual"`
+}
+
+// chapterTiming is a chapter's estimated position in the episode.
+type chapterTiming struct {
+	Words    int
+	Start    int // Seconds from the start of the episode
+	Duration int // Seconds
+}
+
+// timings estimates each chapter's start and duration from the number of
+// spoken words at wpm words per minute. Visual cues are not spoken.
+func (s episodeScript) timings(wpm int) []chapterTiming {
+	timings := make([]chapterTiming, len(s.Chapters))
+	start := 0
+	for i, ch := range s.Chapters {
+		words := 0
+		for _, line := range ch.Lines {
+			words += len(strings.Fields(line.Text))
+		}
+		duration := (words*60 + wpm/2) / wpm
+		timings[i] = chapterTiming{Words: words, Start: start, Duration: duration}
+		start += duration
+	}
+	return timings
+}
+
+// check reports chapters YouTube will not accept and a description over
+// YouTube's limit.
+func (s episodeScript) check(timings []chapterTiming, youtube string) []Diagnostic {
+	var diags []Diagnostic
+	if len(s.Chapters) < youtubeMinChapters {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "chapters",
+			Message:  fmt.Sprintf("%d chapters, YouTube needs at least %d to show them", len(s.Chapters), youtubeMinChapters),
+		})
+	}
+	for i, t := range timings {
+		if t.Duration < youtubeMinChapter {
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				Location: fmt.Sprintf("chapter %d", i+1),
+				Message:  fmt.Sprintf("about %d seconds, YouTube chapters must be at least %d", t.Duration, youtubeMinChapter),
+			})
+		}
+	}
+	if length := len([]rune(youtube)); length > youtubeDescriptionLength {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "youtube.txt",
+			Message:  fmt.Sprintf("%d characters, YouTube allows %d", length, youtubeDescriptionLength),
+		})
+	}
+	return diags
+}
@@ -0,0 +1,69 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the script with each chapter's estimated start time and
+// duration, speaker names in bold and visual cues as blockquotes.
+func (s episodeScript) Markdown(timings []chapterTiming, wpm int) string {
+	total, words := 0, 0
+	for _, t := range timings {
+		total += t.Duration
+		words += t.Words
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", s.Title)
+	fmt.Fprintf(&b, "**Host:** %s  \n**Guest:** %s  \n", s.Host, s.Guest)
+	fmt.Fprintf(&b, "**Estimated runtime:** %s (%d words at %d words per minute)\n", timestamp(total), words, wpm)
+
+	for i, ch := range s.Chapters {
+		t := timings[i]
+		fmt.Fprintf(&b, "\n## %d. %s\n\n", i+1, ch.Title)
+		fmt.Fprintf(&b, "*Chapter marker %s, about %s*\n", timestamp(t.Start), timestamp(t.Duration))
+		for _, line := range ch.Lines {
+			if line.Visual != "" {
+				fmt.Fprintf(&b, "\n> **VISUAL:** %s\n", strings.TrimSpace(line.Visual))
+			}
+			fmt.Fprintf(&b, "\n**%s:** %s\n", s.speakerName(line.Speaker), strings.TrimSpace(line.Text))
+		}
+	}
+	return b.String()
+}
+
+// YouTube renders the video description with chapter timestamps, which
+// YouTube turns into chapters when the first is 0:00.
+func (s episodeScript) YouTube(timings []chapterTiming) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\nChapters:\n", strings.TrimSpace(s.Description))
+	for i, ch := range s.Chapters {
+		fmt.Fprintf(&b, "%s %s\n", timestamp(timings[i].Start), ch.Title)
+	}
+	if len(s.Hashtags) > 0 {
+		tags := make([]string, len(s.Hashtags))
+		for i, tag := range s.Hashtags {
+			tags[i] = "#" + strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		}
+		fmt.Fprintf(&b, "\n%s\n", strings.Join(tags, " "))
+	}
+	return b.String()
+}
+
+// speakerName returns the display name for a line's speaker role.
+func (s episodeScript) speakerName(speaker string) string {
+	if speaker == "guest" {
+		return strings.ToUpper(s.Guest)
+	}
+	return strings.ToUpper(s.Host)
+}
+
+// timestamp formats seconds as m:ss, or h:mm:ss from one hour.
+func timestamp(seconds int) string {
+	h, m, sec := seconds/3600, seconds/60%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%d:%02d", m, sec)
+}
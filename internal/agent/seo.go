@@ -0,0 +1,373 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const seoSystemPrompt = `You are an SEO specialist preparing search and social metadata for a technical article.
+
+Your task is to write metadata that:
+
+1. Has a meta title of 30-60 characters with the main keyword near the start
+2. Has a meta description of 70-160 characters that summarizes the article and gives a reason to click
+3. Has a short, readable URL slug of 3-6 words
+4. Lists 3-5 distinct focus keywords, most important first, as phrases people actually search for
+5. Has an Open Graph title and description for link previews, which may be more conversational than the meta title
+6. Has a headline of at most 110 characters for the article's structured data
+
+Describe what the article actually covers. Do not stuff keywords or promise things the article does not deliver.`
+
+const seoUserPrompt = `Write SEO metadata for an article based on this conversation:
+
+%s`
+
+const seoArticleUserPrompt = `Write SEO metadata for this article:
+
+%s`
+
+// SEO length limits, beyond which search engines and social networks
+// truncate.
+const (
+	seoTitleMin       = 30
+	seoTitleMax       = 60
+	seoDescriptionMin = 70
+	seoDescriptionMax = 160
+	seoSlugMax        = 60
+	seoHeadlineMax    = 110 // Google's limit for Article headline
+	seoOGTitleMax     = 95
+	seoOGDescMax      = 200
+	seoMinKeywords    = 3
+	seoMaxKeywords    = 5
+)
+
+// SEOAgent creates search and social metadata.
+type SEOAgent struct {
+	BaseAgent
+	article     string
+	articleText string // Article generated in the same run, set by the orchestrator
+}
+
+// NewSEOAgent creates a new SEO metadata agent. article, when set, is the
+// path of a generated article (such as blog.md or devto.md) to describe
+// instead of the conversation.
+func NewSEOAgent(client *llm.Client, article string) *SEOAgent {
+	return &SEOAgent{
+		BaseAgent: BaseAgent{
+			name:       "seo",
+			outputFile: "seo.json",
+			client:     client,
+		},
+		article: article,
+	}
+}
+
+// withArticle returns a copy of the agent that describes article, generated
+// in the same run, instead of the conversation.
+func (a *SEOAgent) withArticle(article string) *SEOAgent {
+	c := *a
+	c.articleText = article
+	return &c
+}
+
+// Generate creates SEO metadata from the conversation.
+func (a *SEOAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates SEO metadata as structured output, normalizes the
+// slug and keywords, fills in the Open Graph, Twitter Card and JSON-LD
+// fields, and validates lengths and uniqueness.
+func (a *SEOAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(seoUserPrompt, conv.ToPrompt())
+	switch {
+	case a.articleText != "":
+		prompt = formatPrompt(seoArticleUserPrompt, a.articleText)
+	case a.article != "":
+		article, err := os.ReadFile(a.article)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read article: %w", err)
+		}
+		prompt = formatPrompt(seoArticleUserPrompt, string(article))
+	}
+
+	var draft seoDraft
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(seoSystemPrompt), prompt, seoSchema, &draft); err != nil {
+		return nil, err
+	}
+
+	meta, diags := newSEOMetadata(draft, conv.Metadata)
+	diags = append(diags, meta.check()...)
+
+	content, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode SEO metadata: %w", err)
+	}
+	return &Output{Content: string(content) + "\n", Diagnostics: diags}, nil
+}
+
+// seoSchema is the structured output requested from the model.
+var seoSchema = llm.Schema{
+	Name:        "seo_metadata",
+	Description: "Record the finished SEO metadata.",
+	Properties: map[string]any{
+		"meta_title":       map[string]any{"type": "string", "description": "30-60 characters"},
+		"meta_description": map[string]any{"type": "string", "description": "70-160 characters"},
+		"slug":             map[string]any{"type": "string", "description": "Lowercase words separated by hyphens"},
+		"focus_keywords": map[string]any{
+			"type":        "array",
+			"description": "3-5 distinct search phrases, most important first",
+			"items":       map[string]any{"type": "string"},
+		},
+		"og_title":       map[string]any{"type": "string", "description": "Open Graph title for link previews"},
+		"og_description": map[string]any{"type": "string", "description": "Open Graph description for link previews"},
+		"headline":       map[string]any{"type": "string", "description": "Article headline for structured data, at most 110 characters"},
+		"image_alt":      map[string]any{"type": "string", "description": "Alt text describing a suitable social preview image"},
+	},
+	Required: []string{"meta_title", "meta_description", "slug", "focus_keywords", "og_title", "og_description", "headline"},
+}
+
+// seoDraft is the model's structured metadata.
+type seoDraft struct {
+	MetaTitle       string   `json:"meta_title"`
+	MetaDescription string   `json:"meta_description"`
+	Slug            string   `json:"slug"`
+	FocusKeywords   []string `json:"focus_keywords"`
+	OGTitle         string   `json:"og_title"`
+	OGDescription   string   `json:"og_description"`
+	Headline        string   `json:"headline"`
+	ImageAlt        string   `json:"image_alt"`
+}
+
+// SEOMetadata is the metadata written to seo.json.
+type SEOMetadata struct {
+	MetaTitle       string         `json:"meta_title"`
+	MetaDescription string         `json:"meta_description"`
+	Slug            string         `json:"slug"`
+	CanonicalURL    string         `json:"canonical_url,omitempty"`
+	FocusKeywords   []string       `json:"focus_keywords"`
+	OpenGraph       SEOOpenGraph   `json:"open_graph"`
+	TwitterCard     SEOTwitterCard `json:"twitter_card"`
+	JSONLD          map[string]any `json:"json_ld"`
+}
+
+// SEOOpenGraph holds the og: meta properties.
+type SEOOpenGraph struct {
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url,omitempty"`
+	Image       string `json:"image,omitempty"`
+	ImageAlt    string `json:"image_alt,omitempty"`
+}
+
+// SEOTwitterCard holds the twitter: meta properties.
+type SEOTwitterCard struct {
+	Card        string `json:"card"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image,omitempty"`
+	ImageAlt    string `json:"image_alt,omitempty"`
+}
+
+// seoSlugPattern matches runs of characters not allowed in a slug.
+var seoSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// newSEOMetadata builds the metadata from the model's draft and the
+// conversation's canonical_url, image, author and date metadata, and
+// reports the changes made.
+func newSEOMetadata(d seoDraft, metadata map[string]string) (SEOMetadata, []Diagnostic) {
+	var diags []Diagnostic
+
+	slug := seoSlug(d.Slug)
+	if slug == "" {
+		slug = seoSlug(d.MetaTitle)
+	}
+	if slug != strings.TrimSpace(d.Slug) {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityInfo,
+			Location: "slug",
+			Message:  fmt.Sprintf("normalized %q to %q", d.Slug, slug),
+		})
+	}
+
+	keywords, dropped := uniqueKeywords(d.FocusKeywords)
+	for _, kw := range dropped {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "focus_keywords",
+			Message:  fmt.Sprintf("removed duplicate keyword %q", kw),
+		})
+	}
+
+	canonical := metadata["canonical_url"]
+	if !isAbsoluteURL(canonical) {
+		canonical = ""
+	}
+	image := metadata["image"]
+	if !isAbsoluteURL(image) {
+		image = ""
+	}
+	ogTitle := firstNonEmpty(d.OGTitle, d.MetaTitle)
+	ogDescription := firstNonEmpty(d.OGDescription, d.MetaDescription)
+
+	card := "summary"
+	if image != "" {
+		card = "summary_large_image"
+	}
+	imageAlt := ""
+	if image != "" {
+		imageAlt = d.ImageAlt
+	}
+
+	jsonLD := map[string]any{
+		"@context":    "https://schema.org",
+		"@type":       "Article",
+		"headline":    firstNonEmpty(d.Headline, d.MetaTitle),
+		"description": d.MetaDescription,
+		"keywords":    strings.Join(keywords, ", "),
+	}
+	if canonical != "" {
+		jsonLD["url"] = canonical
+		jsonLD["mainEntityOfPage"] = map[string]any{"@type": "WebPage", "@id": canonical}
+	}
+	if image != "" {
+		jsonLD["image"] = image
+	}
+	if author := metadata["author"]; author != "" {
+		jsonLD["author"] = map[string]any{"@type": "Person", "name": author}
+	}
+	if date := metadata["date"]; date != "" {
+		jsonLD["datePublished"] = date
+	}
+
+	return SEOMetadata{
+		MetaTitle:       strings.TrimSpace(d.MetaTitle),
+		MetaDescription: strings.TrimSpace(d.MetaDescription),
+		Slug:            slug,
+		CanonicalURL:    canonical,
+		FocusKeywords:   keywords,
+		OpenGraph: SEOOpenGraph{
+			Type:        "article",
+			Title:       ogTitle,
+			Description: ogDescription,
+			URL:         canonical,
+			Image:       image,
+			ImageAlt:    imageAlt,
+		},
+		TwitterCard: SEOTwitterCard{
+			Card:        card,
+			Title:       ogTitle,
+			Description: ogDescription,
+			Image:       image,
+			ImageAlt:    imageAlt,
+		},
+		JSONLD: jsonLD,
+	}, diags
+}
+
+// check reports fields outside their length limits, a keyword count
+// outside 3-5, and titles and descriptions that repeat each other.
+func (m SEOMetadata) check() []Diagnostic {
+	var diags []Diagnostic
+	length := func(location, value string, min, max int) {
+		n := len([]rune(value))
+		switch {
+		case n == 0:
+			diags = append(diags, Diagnostic{Severity: SeverityError, Location: location, Message: "empty"})
+		case n > max:
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: fmt.Sprintf("%d characters, limit is %d", n, max)})
+		case n < min:
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: fmt.Sprintf("%d characters, aim for at least %d", n, min)})
+		}
+	}
+	length("meta_title", m.MetaTitle, seoTitleMin, seoTitleMax)
+	length("meta_description", m.MetaDescription, seoDescriptionMin, seoDescriptionMax)
+	length("slug", m.Slug, 1, seoSlugMax)
+	length("open_graph.title", m.OpenGraph.Title, 1, seoOGTitleMax)
+	length("open_graph.description", m.OpenGraph.Description, 1, seoOGDescMax)
+	if headline, _ := m.JSONLD["headline"].(string); headline != "" {
+		length("json_ld.headline", headline, 1, seoHeadlineMax)
+	}
+
+	if n := len(m.FocusKeywords); n < seoMinKeywords || n > seoMaxKeywords {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "focus_keywords",
+			Message:  fmt.Sprintf("%d keywords, expected %d-%d", n, seoMinKeywords, seoMaxKeywords),
+		})
+	}
+	if strings.EqualFold(m.MetaTitle, m.MetaDescription) {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: "meta_description",
+			Message:  "repeats the meta title",
+		})
+	}
+	if len(m.FocusKeywords) > 0 && !strings.Contains(strings.ToLower(m.MetaTitle+" "+m.MetaDescription), strings.ToLower(m.FocusKeywords[0])) {
+		diags = append(diags, Diagnostic{
+			Severity: SeverityInfo,
+			Location: "focus_keywords",
+			Message:  fmt.Sprintf("main keyword %q is not in the meta title or description", m.FocusKeywords[0]),
+		})
+	}
+	return diags
+}
+
+// seoSlug lowercases s, replaces runs of other characters with hyphens, and
+// shortens it to seoSlugMax at a hyphen.
+func seoSlug(s string) string {
+	slug := strings.Trim(seoSlugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	for len(slug) > seoSlugMax {
+		i := strings.LastIndex(slug[:seoSlugMax], "-")
+		if i <= 0 {
+			return slug[:seoSlugMax]
+		}
+		slug = slug[:i]
+	}
+	return slug
+}
+
+// uniqueKeywords trims keywords and drops empty ones and case-insensitive
+// duplicates, returning the kept keywords and the dropped duplicates.
+func uniqueKeywords(keywords []string) ([]string, []string) {
+	var (
+		kept    = []string{}
+		dropped []string
+		seen    = map[string]bool{}
+	)
+	for _, kw := range keywords {
+		kw = strings.TrimSpace(kw)
+		key := strings.ToLower(kw)
+		switch {
+		case kw == "":
+		case seen[key]:
+			dropped = append(dropped, kw)
+		default:
+			seen[key] = true
+			kept = append(kept, kw)
+		}
+	}
+	return kept, dropped
+}
+
+// firstNonEmpty returns the first of values that is not blank, trimmed.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/thread"
+)
+
+// socialSchema returns the structured output requested from the model for a
+// thread on network. With contentWarning, the model may also suggest a
+// content warning for the thread.
+func socialSchema(network string, contentWarning bool) llm.Schema {
+	schema := llm.Schema{
+		Name:        network + "_thread",
+		Description: fmt.Sprintf("Record the finished %s thread. List posts in order. Do not include the 1/, 2/ numbering in the post text; it is added automatically.", network),
+		Properties: map[string]any{
+			"posts": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"text": map[string]any{
+							"type":        "string",
+							"description": "Post text without the number prefix, including any hashtags",
+						},
+						"media": map[string]any{
+							"type":        "object",
+							"description": "Optional image, chart or video suggested for this post",
+							"properties": map[string]any{
+								"description": map[string]any{"type": "string", "description": "What the media should show"},
+								"alt_text":    map[string]any{"type": "string", "description": "Accessible alt text for the media"},
+							},
+							"required": []string{"description", "alt_text"},
+						},
+					},
+					"required": []string{"text"},
+				},
+			},
+		},
+		Required: []string{"posts"},
+	}
+	if contentWarning {
+		schema.Properties["content_warning"] = map[string]any{
+			"type":        "string",
+			"description": "Short content warning for the whole thread, or empty if none is needed",
+		}
+	}
+	return schema
+}
+
+// socialStructured is the model's structured thread.
+type socialStructured struct {
+	Posts []struct {
+		Text  string        `json:"text"`
+		Media *thread.Media `json:"media,omitempty"`
+	} `json:"posts"`
+	ContentWarning string `json:"content_warning"`
+}
+
+// threadPosts numbers the model's posts, applying contentWarning to each.
+func (s socialStructured) threadPosts(contentWarning string) []thread.Post {
+	var posts []thread.Post
+	for i, p := range s.Posts {
+		post := thread.Post{Number: i + 1, Text: thread.StripMarker(p.Text), ContentWarning: contentWarning}
+		if p.Media != nil && p.Media.Description != "" {
+			post.Media = p.Media
+		}
+		posts = append(posts, post)
+	}
+	return posts
+}
+
+// socialOutput repairs and checks posts against network and returns the
+// thread as Markdown plus a <network>.json artifact. Length and numbering
+// problems left after repair are errors; mentions and hashtags that break
+// the network's conventions are warnings.
+func socialOutput(network thread.Network, posts []thread.Post) (*Output, error) {
+	if len(posts) == 0 {
+		return nil, fmt.Errorf("model returned an empty thread")
+	}
+
+	var diags []Diagnostic
+	posts, changes := network.Repair(posts)
+	for _, change := range changes {
+		diags = append(diags, threadDiagnostic(SeverityWarning, "post", change, ""))
+	}
+	for _, issue := range network.Check(posts) {
+		severity := SeverityError
+		if issue.Kind == thread.IssueHandle || issue.Kind == thread.IssueHashtag {
+			severity = SeverityWarning
+		}
+		diags = append(diags, threadDiagnostic(severity, "post", issue, ""))
+	}
+
+	threadJSON, err := json.MarshalIndent(newSocialThread(network, posts), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thread: %w", err)
+	}
+
+	content := thread.Render(posts)
+	if cw := posts[0].ContentWarning; cw != "" {
+		content = fmt.Sprintf("**CW:** %s\n\n%s", cw, content)
+	}
+	return &Output{
+		Content:     content,
+		Artifacts:   []Artifact{{File: network.Name + ".json", Content: string(threadJSON) + "\n"}},
+		Diagnostics: diags,
+	}, nil
+}
+
+// SocialThread is the machine-readable thread written to bluesky.json and
+// mastodon.json.
+type SocialThread struct {
+	Network   string       `json:"network"`
+	MaxLength int          `json:"max_length"`
+	Posts     []SocialPost `json:"posts"`
+}
+
+// SocialPost is one post in a SocialThread. Text includes the "N/" marker
+// exactly as it should be posted; Characters is its length under the
+// network's rules, including the content warning.
+type SocialPost struct {
+	Number         int           `json:"number"`
+	Text           string        `json:"text"`
+	ContentWarning string        `json:"content_warning,omitempty"`
+	Characters     int           `json:"characters"`
+	Hashtags       []string      `json:"hashtags"`
+	Mentions       []string      `json:"mentions"`
+	Media          *thread.Media `json:"media,omitempty"`
+}
+
+func newSocialThread(network thread.Network, posts []thread.Post) SocialThread {
+	t := SocialThread{
+		Network:   network.Name,
+		MaxLength: network.MaxLength,
+		Posts:     make([]SocialPost, 0, len(posts)),
+	}
+	for _, p := range posts {
+		t.Posts = append(t.Posts, SocialPost{
+			Number:         p.Number,
+			Text:           thread.Format(p),
+			ContentWarning: p.ContentWarning,
+			Characters:     network.PostLength(p),
+			Hashtags:       nonNil(thread.Hashtags(p.Text)),
+			Mentions:       nonNil(thread.Mentions(p.Text)),
+			Media:          p.Media,
+		})
+	}
+	return t
+}
+
+// nonNil returns items, or an empty slice if it is nil, so it encodes as
+// [] rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// contentWarning returns the content warning to apply to a thread: the
+// configured one if set, otherwise the model's suggestion.
+func contentWarning(configured, suggested string) string {
+	if configured != "" {
+		return strings.TrimSpace(configured)
+	}
+	return strings.TrimSpace(suggested)
+}
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SpecPath returns the path of an agent's spec file within a specs directory.
+func SpecPath(specsDir, name string) string {
+	return filepath.Join(specsDir, "agents", name+".md")
+}
+
+// LoadSpecPrompt reads an agent spec and returns its body, without the YAML
+// frontmatter, for use as the agent's system prompt. It returns an empty
+// string if the agent has no spec file.
+func LoadSpecPrompt(specsDir, name string) (string, error) {
+	data, err := os.ReadFile(SpecPath(specsDir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read spec for %s: %w", name, err)
+	}
+
+	body := strings.TrimSpace(string(data))
+	if strings.HasPrefix(body, "---") {
+		end := strings.Index(body[3:], "\n---")
+		if end < 0 {
+			return "", fmt.Errorf("spec for %s has unterminated frontmatter", name)
+		}
+		body = strings.TrimSpace(body[3+end+len("\n---"):])
+	}
+
+	return body, nil
+}
+
+// applySpecs replaces the built-in system prompts of agents that have a spec
+// file in specsDir.
+func applySpecs(agents []Agent, specsDir string) error {
+	if specsDir == "" {
+		return nil
+	}
+	for _, a := range agents {
+		s, ok := a.(interface{ setSpecPrompt(string) })
+		if !ok {
+			continue
+		}
+		prompt, err := LoadSpecPrompt(specsDir, a.Name())
+		if err != nil {
+			return err
+		}
+		s.setSpecPrompt(prompt)
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const summarySystemPrompt = `You are a chief of staff summarizing a conversation for busy leaders who will not read it.
+
+Your task is to summarize the conversation at three lengths and extract what was decided and what happens next:
+
+1. A one-line TL;DR of at most 25 words: the outcome, not the topic
+2. A one-paragraph summary of 3-5 sentences: the question, what was found, and what was decided
+3. A one-page summary of 300-500 words in Markdown, using ### headings for context, findings, options considered and recommendation
+4. Every decision made, with its rationale and who made it
+5. Every action item, with its owner and due date if one was stated
+6. Open questions that still need an answer
+
+Lead with conclusions. Leave out the back-and-forth. Only record decisions and action items the conversation actually contains; do not invent owners or dates.`
+
+const summaryUserPrompt = `Summarize this conversation:
+
+%s`
+
+// summarySpeakersPrompt lists the named speakers, whom action items should
+// be assigned to.
+const summarySpeakersPrompt = `
+
+The named speakers are: %s. Use these names for decision makers and action item owners.`
+
+// Length limits for the summaries, in words. They are a little above the
+// limits in the prompt, so that only clear overruns are reported.
+const (
+	summaryOneLineWords   = 30
+	summaryParagraphWords = 150
+	summaryOnePageWords   = 650
+)
+
+// summaryReportFile is the summary agent's JSON artifact, named so it does
+// not overwrite the run's summary.json.
+const summaryReportFile = "summary-report.json"
+
+// SummaryAgent creates executive summaries with decisions and action items.
+type SummaryAgent struct {
+	BaseAgent
+}
+
+// NewSummaryAgent creates a new summary agent.
+func NewSummaryAgent(client *llm.Client) *SummaryAgent {
+	return &SummaryAgent{
+		BaseAgent: BaseAgent{
+			name:       "summary",
+			outputFile: "summary.md",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates a summary of the conversation.
+func (a *SummaryAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates one-line, one-paragraph and one-page summaries,
+// decisions and action items as structured output, and returns them as
+// Markdown and as summary-report.json. Action item owners are checked
+// against the conversation's named speakers.
+func (a *SummaryAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(summaryUserPrompt, conv.ToPrompt())
+	system := a.systemPrompt(summarySystemPrompt)
+	speakers := conv.Speakers()
+	if len(speakers) > 0 {
+		system += fmt.Sprintf(summarySpeakersPrompt, strings.Join(speakers, ", "))
+	}
+
+	var report SummaryReport
+	if err := a.client.GenerateJSON(ctx, system, prompt, summarySchema, &report); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(report.OneLine) == "" && strings.TrimSpace(report.Paragraph) == "" {
+		return nil, fmt.Errorf("model returned an empty summary")
+	}
+	report.Title = firstNonEmpty(conv.Title, "Summary")
+	report.Decisions = nonNil(report.Decisions)
+	report.ActionItems = nonNil(report.ActionItems)
+	report.OpenQuestions = nonNil(report.OpenQuestions)
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode summary: %w", err)
+	}
+	return &Output{
+		Content:     report.Markdown(),
+		Artifacts:   []Artifact{{File: summaryReportFile, Content: string(content) + "\n"}},
+		Diagnostics: report.check(conv, speakers),
+	}, nil
+}
+
+// summarySchema is the structured output requested from the model.
+var summarySchema = llm.Schema{
+	Name:        "executive_summary",
+	Description: "Record the summaries, decisions and action items.",
+	Properties: map[string]any{
+		"one_line":  map[string]any{"type": "string", "description": "TL;DR of at most 25 words"},
+		"paragraph": map[string]any{"type": "string", "description": "Summary of 3-5 sentences"},
+		"one_page":  map[string]any{"type": "string", "description": "Summary of 300-500 words in Markdown with ### headings"},
+		"decisions": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"decision":  map[string]any{"type": "string"},
+					"rationale": map[string]any{"type": "string"},
+					"made_by":   map[string]any{"type": "string", "description": "Who made the decision, or empty if not stated"},
+				},
+				"required": []string{"decision"},
+			},
+		},
+		"action_items": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"task":  map[string]any{"type": "string"},
+					"owner": map[string]any{"type": "string", "description": "Who will do it, or empty if not stated"},
+					"due":   map[string]any{"type": "string", "description": "Due date as stated, or empty"},
+				},
+				"required": []string{"task", "owner"},
+			},
+		},
+		"open_questions": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+	},
+	Required: []string{"one_line", "paragraph", "one_page", "decisions", "action_items"},
+}
+
+// SummaryReport is the summary written to summary-report.json.
+type SummaryReport struct {
+	Title         string            `json:"title"`
+	OneLine       string            `json:"one_line"`
+	Paragraph     string            `json:"paragraph"`
+	OnePage       string            `json:"one_page"`
+	Decisions     []SummaryDecision `json:"decisions"`
+	ActionItems   []ActionItem      `json:"action_items"`
+	OpenQuestions []string          `json:"open_questions"`
+}
+
+// SummaryDecision is a decision made in the conversation.
+type SummaryDecision struct {
+	Decision  string `json:"decision"`
+	Rationale string `json:"rationale,omitempty"`
+	MadeBy    string `json:"made_by,omitempty"`
+}
+
+// ActionItem is a task agreed in the conversation.
+type ActionItem struct {
+	Task  string `json:"task"`
+	Owner string `json:"owner"` // Empty if no owner was stated
+	Due   string `json:"due,omitempty"`
+}
+
+// Markdown renders the TL;DR and paragraph, the decisions, action items and
+// open questions, and the one-page summary last as details.
+func (r SummaryReport) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	fmt.Fprintf(&b, "**TL;DR:** %s\n\n", strings.TrimSpace(r.OneLine))
+	fmt.Fprintf(&b, "## Summary\n\n%s\n\n", strings.TrimSpace(r.Paragraph))
+
+	if len(r.Decisions) > 0 {
+		b.WriteString("## Decisions\n\n")
+		for _, d := range r.Decisions {
+			line := strings.TrimSpace(d.Decision)
+			if d.MadeBy != "" {
+				line += " (" + d.MadeBy + ")"
+			}
+			if d.Rationale != "" {
+				line += " — " + strings.TrimSpace(d.Rationale)
+			}
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.ActionItems) > 0 {
+		b.WriteString("## Action Items\n\n| Task | Owner | Due |\n|------|-------|-----|\n")
+		for _, item := range r.ActionItems {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", tableCell(item.Task), tableCell(firstNonEmpty(item.Owner, "Unassigned")), tableCell(item.Due))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.OpenQuestions) > 0 {
+		b.WriteString("## Open Questions\n\n")
+		for _, q := range r.OpenQuestions {
+			fmt.Fprintf(&b, "- %s\n", strings.TrimSpace(q))
+		}
+		b.WriteString("\n")
+	}
+
+	if r.OnePage != "" {
+		fmt.Fprintf(&b, "## Details\n\n%s\n", strings.TrimSpace(r.OnePage))
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// check reports summaries over their length limits, and action items
+// without an owner or whose owner is neither a named speaker nor mentioned
+// in the conversation.
+func (r SummaryReport) check(conv *conversation.Conversation, speakers []string) []Diagnostic {
+	var diags []Diagnostic
+	length := func(location, text string, max int) {
+		if n := len(strings.Fields(text)); n > max {
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: fmt.Sprintf("%d words, keep it under %d", n, max)})
+		}
+	}
+	length("one_line", r.OneLine, summaryOneLineWords)
+	length("paragraph", r.Paragraph, summaryParagraphWords)
+	length("one_page", r.OnePage, summaryOnePageWords)
+
+	for i, item := range r.ActionItems {
+		location := fmt.Sprintf("action item %d", i+1)
+		switch owner := strings.TrimSpace(item.Owner); {
+		case owner == "":
+			diags = append(diags, Diagnostic{Severity: SeverityWarning, Location: location, Message: "no owner"})
+		case len(speakers) > 0 && !containsFold(speakers, owner) && !inConversation(conv, owner):
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				Location: location,
+				Message:  fmt.Sprintf("owner %q is not a speaker and is not mentioned in the conversation", owner),
+			})
+		}
+	}
+	return diags
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// tableCell escapes text for a Markdown table cell.
+func tableCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
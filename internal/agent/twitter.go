@@ -2,9 +2,12 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/agentplexus/agent-team-content/internal/conversation"
 	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/thread"
 )
 
 const twitterSystemPrompt = `You are a Twitter/X content creator specializing in viral threads.
@@ -12,22 +15,13 @@ const twitterSystemPrompt = `You are a Twitter/X content creator specializing in
 Your task is to transform a conversation into a Twitter thread that:
 
 1. Opens with a hook tweet that makes people want to read more
-2. Numbers each tweet (1/, 2/, etc.)
-3. Keeps each tweet under 280 characters
-4. Uses clear, punchy language
-5. Includes relevant insights broken into digestible pieces
-6. Ends with a summary or call-to-action tweet
-7. Suggests relevant hashtags for the final tweet
+2. Keeps each tweet under 280 characters, leaving room for the 1/, 2/ numbering that is added to each tweet automatically
+3. Uses clear, punchy language
+4. Includes relevant insights broken into digestible pieces
+5. Ends with a summary or call-to-action tweet
+6. Suggests relevant hashtags for the final tweet
 
-Format the output as a numbered thread:
-
-1/ [Hook tweet - grab attention]
-
-2/ [Key insight #1]
-
-3/ [Key insight #2]
-
-... and so on
+Write the tweets in order, without numbering them yourself.
 
 Final tweet should encourage engagement (retweet, follow, etc.) and include 2-3 relevant hashtags.
 
@@ -39,24 +33,227 @@ const twitterUserPrompt = `Transform this conversation into a Twitter/X thread:
 
 Create an engaging thread that breaks down the key insights into tweet-sized pieces that people will want to read and share.`
 
+const twitterFixupPrompt = `The following tweets from a numbered Twitter/X thread are over the 280 character limit. X counts every URL as 23 characters and emoji and CJK characters as 2.
+
+%s
+
+Rewrite each of these tweets so it fits within 280 characters, keeping its number, meaning and voice. Return only the rewritten tweets in the same "N/ text" format, separated by blank lines.`
+
 // TwitterAgent creates Twitter/X threads.
 type TwitterAgent struct {
 	BaseAgent
+	repair string
 }
 
-// NewTwitterAgent creates a new Twitter thread agent.
-func NewTwitterAgent(client *llm.Client) *TwitterAgent {
+// NewTwitterAgent creates a new Twitter thread agent. repair selects how
+// tweets over the length limit are fixed: RepairLocal splits them, RepairLLM
+// asks the model to rewrite them first.
+func NewTwitterAgent(client *llm.Client, repair string) *TwitterAgent {
+	if repair == "" {
+		repair = RepairLocal
+	}
+
 	return &TwitterAgent{
 		BaseAgent: BaseAgent{
 			name:       "twitter",
 			outputFile: "twitter.md",
 			client:     client,
 		},
+		repair: repair,
 	}
 }
 
 // Generate creates a Twitter thread from the conversation.
 func (a *TwitterAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput creates a Twitter thread from the conversation as
+// structured output, validates tweet lengths and numbering, repairs what it
+// can, and returns the thread as Markdown plus a twitter.json artifact.
+func (a *TwitterAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
 	prompt := formatPrompt(twitterUserPrompt, conv.ToPrompt())
-	return a.client.Generate(ctx, twitterSystemPrompt, prompt)
+
+	var structured twitterStructured
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(twitterSystemPrompt), prompt, twitterSchema, &structured); err != nil {
+		return nil, err
+	}
+
+	var posts []thread.Post
+	for i, t := range structured.Tweets {
+		post := thread.Post{Number: i + 1, Text: thread.StripMarker(t.Text)}
+		if t.Media != nil && t.Media.Description != "" {
+			post.Media = t.Media
+		}
+		posts = append(posts, post)
+	}
+	if len(posts) == 0 {
+		return nil, fmt.Errorf("model returned an empty thread")
+	}
+
+	var diags []Diagnostic
+	if a.repair == RepairLLM {
+		posts, diags = a.rewriteLong(ctx, posts, diags)
+	}
+
+	posts, changes := thread.Twitter.Repair(posts)
+	for _, change := range changes {
+		diags = append(diags, threadDiagnostic(SeverityWarning, "tweet", change, ""))
+	}
+
+	for _, issue := range thread.Twitter.Check(posts) {
+		diags = append(diags, threadDiagnostic(SeverityError, "tweet", issue, ""))
+	}
+
+	threadJSON, err := json.MarshalIndent(newTwitterThread(posts), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thread: %w", err)
+	}
+
+	return &Output{
+		Content:     thread.Render(posts),
+		Artifacts:   []Artifact{{File: "twitter.json", Content: string(threadJSON) + "\n"}},
+		Diagnostics: diags,
+	}, nil
+}
+
+// twitterSchema is the structured output requested from the model.
+var twitterSchema = llm.Schema{
+	Name:        "twitter_thread",
+	Description: "Record the finished Twitter/X thread. List tweets in order. Do not include the 1/, 2/ numbering in the tweet text; it is added automatically.",
+	Properties: map[string]any{
+		"tweets": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"text": map[string]any{
+						"type":        "string",
+						"description": "Tweet text without the number prefix, including any hashtags",
+					},
+					"media": map[string]any{
+						"type":        "object",
+						"description": "Optional image, chart or video suggested for this tweet",
+						"properties": map[string]any{
+							"description": map[string]any{"type": "string", "description": "What the media should show"},
+							"alt_text":    map[string]any{"type": "string", "description": "Accessible alt text for the media"},
+						},
+						"required": []string{"description", "alt_text"},
+					},
+				},
+				"required": []string{"text"},
+			},
+		},
+	},
+	Required: []string{"tweets"},
+}
+
+// twitterStructured is the model's structured thread.
+type twitterStructured struct {
+	Tweets []struct {
+		Text  string        `json:"text"`
+		Media *thread.Media `json:"media,omitempty"`
+	} `json:"tweets"`
+}
+
+// TwitterThread is the machine-readable thread written to twitter.json.
+type TwitterThread struct {
+	Network   string         `json:"network"`
+	MaxLength int            `json:"max_length"`
+	Tweets    []TwitterTweet `json:"tweets"`
+}
+
+// TwitterTweet is one tweet in twitter.json. Text includes the "N/" marker
+// exactly as it should be posted; Characters is its weighted length.
+type TwitterTweet struct {
+	Number     int           `json:"number"`
+	Text       string        `json:"text"`
+	Characters int           `json:"characters"`
+	Hashtags   []string      `json:"hashtags"`
+	Media      *thread.Media `json:"media,omitempty"`
+}
+
+func newTwitterThread(posts []thread.Post) TwitterThread {
+	t := TwitterThread{
+		Network:   thread.Twitter.Name,
+		MaxLength: thread.Twitter.MaxLength,
+		Tweets:    make([]TwitterTweet, 0, len(posts)),
+	}
+	for _, p := range posts {
+		t.Tweets = append(t.Tweets, TwitterTweet{
+			Number:     p.Number,
+			Text:       thread.Format(p),
+			Characters: thread.Twitter.PostLength(p),
+			Hashtags:   nonNil(thread.Hashtags(p.Text)),
+			Media:      p.Media,
+		})
+	}
+	return t
+}
+
+// rewriteLong sends only the over-length tweets back to the model for a
+// shorter rewrite. Rewrites that are still too long are left for local
+// repair.
+func (a *TwitterAgent) rewriteLong(ctx context.Context, posts []thread.Post, diags []Diagnostic) ([]thread.Post, []Diagnostic) {
+	var long []thread.Post
+	for _, issue := range thread.Twitter.Check(posts) {
+		if issue.Kind == thread.IssueTooLong {
+			long = append(long, posts[issue.Post-1])
+		}
+	}
+	if len(long) == 0 {
+		return posts, diags
+	}
+
+	rewritten, err := a.client.Generate(ctx, a.systemPrompt(twitterSystemPrompt), formatPrompt(twitterFixupPrompt, thread.Render(long)))
+	if err != nil {
+		return posts, append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("fix-up request failed, splitting locally: %v", err),
+		})
+	}
+
+	fixed, _ := thread.Parse(rewritten)
+	byNumber := make(map[int]string, len(fixed))
+	for _, p := range fixed {
+		byNumber[p.Number] = p.Text
+	}
+
+	out := append([]thread.Post(nil), posts...)
+	for _, p := range long {
+		text, ok := byNumber[p.Number]
+		if !ok {
+			continue
+		}
+		candidate := thread.Post{Number: p.Number, Text: text, Media: p.Media}
+		if thread.Twitter.PostLength(candidate) > thread.Twitter.MaxLength {
+			continue
+		}
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			Location: fmt.Sprintf("tweet %d", p.Number),
+			Message:  fmt.Sprintf("%d characters, rewritten to %d", thread.Twitter.PostLength(p), thread.Twitter.PostLength(candidate)),
+		})
+		out[p.Number-1] = candidate
+	}
+	return out, diags
+}
+
+// threadDiagnostic converts a thread issue into a diagnostic located at the
+// post, called noun on its network, optionally appending a note about how
+// it was handled.
+func threadDiagnostic(severity, noun string, issue thread.Issue, note string) Diagnostic {
+	msg := issue.Message
+	if note != "" {
+		msg += "; " + note
+	}
+	return Diagnostic{
+		Severity: severity,
+		Location: fmt.Sprintf("%s %d", noun, issue.Post),
+		Message:  msg,
+	}
 }
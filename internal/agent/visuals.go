@@ -0,0 +1,261 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+)
+
+const visualsSystemPrompt = `You are an art director planning the visuals for content derived from a technical conversation: a blog article, social posts and slides.
+
+Your task is to propose visuals that:
+
+1. Include one cover image that represents the whole piece and works as a social preview
+2. Include an illustration for each major section or idea (3-6 in total), each tied to a short section title
+3. Suggest diagrams (flowcharts, sequence diagrams, architecture diagrams, charts) wherever the conversation describes a process, system or comparison
+4. Describe images as prompts for an image generation model: subject, composition, style, colors and mood; no text rendered in the image
+5. Share one consistent visual style across all images
+6. Give every visual alt text for screen readers: what the image shows and why it matters, in at most 125 characters, without starting with "image of" or "picture of"
+
+Diagram descriptions should name every box, actor and arrow so the diagram can be drawn without the conversation.`
+
+const visualsUserPrompt = `Propose visuals for content based on this conversation:
+
+%s`
+
+// Visual kinds in visuals.json.
+const (
+	VisualCover        = "cover"
+	VisualIllustration = "illustration"
+	VisualDiagram      = "diagram"
+)
+
+// Suggested image sizes: the Open Graph preview size for covers, and 16:9
+// for illustrations.
+const (
+	visualCoverSize        = "1200x630"
+	visualIllustrationSize = "1600x900"
+)
+
+// altTextLength is the alt text length screen readers handle well.
+const altTextLength = 125
+
+// visualsDir is the directory visuals.json suggests for image files.
+const visualsDir = "images"
+
+// VisualsAgent proposes image prompts, diagrams and alt text.
+type VisualsAgent struct {
+	BaseAgent
+}
+
+// NewVisualsAgent creates a new visuals agent.
+func NewVisualsAgent(client *llm.Client) *VisualsAgent {
+	return &VisualsAgent{
+		BaseAgent: BaseAgent{
+			name:       "visuals",
+			outputFile: "visuals.json",
+			client:     client,
+		},
+	}
+}
+
+// Generate creates visual suggestions from the conversation.
+func (a *VisualsAgent) Generate(ctx context.Context, conv *conversation.Conversation) (string, error) {
+	out, err := a.GenerateOutput(ctx, conv)
+	if err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
+
+// GenerateOutput proposes visuals as structured output and returns them as
+// visuals.json, giving each a stable ID, a suggested file name and a
+// Markdown image reference, and checking their alt text.
+func (a *VisualsAgent) GenerateOutput(ctx context.Context, conv *conversation.Conversation) (*Output, error) {
+	prompt := formatPrompt(visualsUserPrompt, conv.ToPrompt())
+
+	var draft visualsDraft
+	if err := a.client.GenerateJSON(ctx, a.systemPrompt(visualsSystemPrompt), prompt, visualsSchema, &draft); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(draft.Cover.Prompt) == "" {
+		return nil, fmt.Errorf("model returned no cover image")
+	}
+
+	set := draft.visualSet()
+	content, err := json.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode visuals: %w", err)
+	}
+	return &Output{Content: string(content) + "\n", Diagnostics: set.check()}, nil
+}
+
+// visualsSchema is the structured output requested from the model.
+var visualsSchema = llm.Schema{
+	Name:        "visual_plan",
+	Description: "Record the proposed visuals.",
+	Properties: map[string]any{
+		"style": map[string]any{"type": "string", "description": "The visual style shared by every image"},
+		"cover": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"prompt":   map[string]any{"type": "string", "description": "Image generation prompt"},
+				"alt_text": map[string]any{"type": "string"},
+			},
+			"required": []string{"prompt", "alt_text"},
+		},
+		"illustrations": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"section":  map[string]any{"type": "string", "description": "Short title of the section or idea illustrated"},
+					"prompt":   map[string]any{"type": "string", "description": "Image generation prompt"},
+					"alt_text": map[string]any{"type": "string"},
+				},
+				"required": []string{"section", "prompt", "alt_text"},
+			},
+		},
+		"diagrams": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title":       map[string]any{"type": "string"},
+					"section":     map[string]any{"type": "string", "description": "Section the diagram belongs to"},
+					"type":        map[string]any{"type": "string", "enum": []string{"flowchart", "sequence", "architecture", "class", "state", "chart", "comparison"}},
+					"description": map[string]any{"type": "string", "description": "Every box, actor and arrow in the diagram"},
+					"alt_text":    map[string]any{"type": "string"},
+				},
+				"required": []string{"title", "type", "description", "alt_text"},
+			},
+		},
+	},
+	Required: []string{"style", "cover", "illustrations", "diagrams"},
+}
+
+// visualsDraft is the model's structured visual plan.
+type visualsDraft struct {
+	Style string `json:"style"`
+	Cover struct {
+		Prompt  string `json:"prompt"`
+		AltText string `json:"alt_text"`
+	} `json:"cover"`
+	Illustrations []struct {
+		Section string `json:"section"`
+		Prompt  string `json:"prompt"`
+		AltText string `json:"alt_text"`
+	} `json:"illustrations"`
+	Diagrams []struct {
+		Title       string `json:"title"`
+		Section     string `json:"section"`
+		Type        string `json:"type"`
+		Description string `json:"description"`
+		AltText     string `json:"alt_text"`
+	} `json:"diagrams"`
+}
+
+// VisualSet is the visual plan written to visuals.json.
+type VisualSet struct {
+	Style   string   `json:"style"`
+	Visuals []Visual `json:"visuals"`
+}
+
+// Visual is one proposed image or diagram. Other outputs refer to it by
+// ID, or embed Markdown once File has been created.
+type Visual struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`
+	Section     string `json:"section,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Prompt      string `json:"prompt,omitempty"`       // Image generation prompt, for covers and illustrations
+	DiagramType string `json:"diagram_type,omitempty"` // For diagrams
+	Description string `json:"description,omitempty"`  // What the diagram shows, for diagrams
+	Size        string `json:"size,omitempty"`         // Suggested size in pixels
+	AltText     string `json:"alt_text"`
+	File        string `json:"file"`
+	Markdown    string `json:"markdown"`
+}
+
+// visualIDPattern matches runs of characters not allowed in a visual ID.
+var visualIDPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// visualSet assigns IDs, files and Markdown references to the draft's
+// visuals, cover first.
+func (d visualsDraft) visualSet() VisualSet {
+	set := VisualSet{Style: strings.TrimSpace(d.Style)}
+	ids := map[string]int{}
+	add := func(v Visual, name string) {
+		base := v.Kind
+		if slug := strings.Trim(visualIDPattern.ReplaceAllString(strings.ToLower(name), "-"), "-"); slug != "" {
+			base += "-" + slug
+		}
+		ids[base]++
+		v.ID = base
+		if n := ids[base]; n > 1 {
+			v.ID = fmt.Sprintf("%s-%d", base, n)
+		}
+		v.AltText = strings.TrimSpace(v.AltText)
+		v.File = visualsDir + "/" + v.ID + ".png"
+		v.Markdown = fmt.Sprintf("![%s](%s)", strings.ReplaceAll(v.AltText, "]", `\]`), v.File)
+		set.Visuals = append(set.Visuals, v)
+	}
+
+	add(Visual{Kind: VisualCover, Prompt: d.Cover.Prompt, Size: visualCoverSize, AltText: d.Cover.AltText}, "")
+	for _, ill := range d.Illustrations {
+		add(Visual{Kind: VisualIllustration, Section: ill.Section, Prompt: ill.Prompt, Size: visualIllustrationSize, AltText: ill.AltText}, ill.Section)
+	}
+	for _, dia := range d.Diagrams {
+		add(Visual{Kind: VisualDiagram, Section: dia.Section, Title: dia.Title, DiagramType: dia.Type, Description: dia.Description, AltText: dia.AltText}, dia.Title)
+	}
+	return set
+}
+
+// altTextPrefixes are redundant openings, since screen readers already
+// announce an image.
+var altTextPrefixes = []string{"image of", "picture of", "photo of", "an image of", "a picture of", "a photo of", "illustration of", "an illustration of"}
+
+// check reports visuals without alt text, alt text that is too long or
+// starts redundantly, and alt text repeated between visuals.
+func (s VisualSet) check() []Diagnostic {
+	var diags []Diagnostic
+	seen := map[string]string{}
+	for _, v := range s.Visuals {
+		alt := strings.ToLower(v.AltText)
+		switch {
+		case alt == "":
+			diags = append(diags, Diagnostic{Severity: SeverityError, Location: v.ID, Message: "missing alt text"})
+			continue
+		case len([]rune(v.AltText)) > altTextLength:
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				Location: v.ID,
+				Message:  fmt.Sprintf("alt text is %d characters, keep it under %d", len([]rune(v.AltText)), altTextLength),
+			})
+		}
+		for _, prefix := range altTextPrefixes {
+			if strings.HasPrefix(alt, prefix+" ") {
+				diags = append(diags, Diagnostic{
+					Severity: SeverityWarning,
+					Location: v.ID,
+					Message:  fmt.Sprintf("alt text starts with %q, which screen readers already announce", prefix),
+				})
+				break
+			}
+		}
+		if other, ok := seen[alt]; ok {
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				Location: v.ID,
+				Message:  fmt.Sprintf("same alt text as %s", other),
+			})
+		}
+		seen[alt] = v.ID
+	}
+	return diags
+}
@@ -0,0 +1,114 @@
+// Package brand loads brand kits: the names, voice, wording rules and deck
+// styling that every generated output should follow.
+package brand
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kit is a brand kit, loaded from YAML (or JSON).
+type Kit struct {
+	Company     string   `yaml:"company"`
+	Products    []string `yaml:"products"` // Product names with their correct casing
+	Voice       []string `yaml:"voice"`    // Voice and tone descriptors, e.g. "friendly"
+	BannedWords []string `yaml:"banned_words"`
+	CTAs        []string `yaml:"ctas"`     // Preferred calls to action
+	Hashtags    []string `yaml:"hashtags"` // Default hashtags, with or without #
+	Deck        Deck     `yaml:"deck"`
+
+	// Path is the file the kit was loaded from.
+	Path string `yaml:"-"`
+}
+
+// Deck holds the styling applied to presentations.
+type Deck struct {
+	Logo   string `yaml:"logo"` // Image path or URL shown on every slide
+	Colors Colors `yaml:"colors"`
+}
+
+// Colors are CSS colors for decks. Unset colors keep the theme's.
+type Colors struct {
+	Primary    string `yaml:"primary"` // Headings
+	Accent     string `yaml:"accent"`  // Links and emphasis
+	Background string `yaml:"background"`
+	Text       string `yaml:"text"`
+}
+
+// colorPattern matches a hex color or a CSS color keyword.
+var colorPattern = regexp.MustCompile(`^(#[0-9A-Fa-f]{3,8}|[A-Za-z]+)$`)
+
+// Load reads a brand kit file. Unknown keys and invalid colors are errors.
+func Load(path string) (*Kit, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read brand kit: %w", err)
+	}
+
+	var k Kit
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&k); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid brand kit %s: %w", path, err)
+	}
+	k.Path = path
+
+	colors := []struct{ name, value string }{
+		{"primary", k.Deck.Colors.Primary},
+		{"accent", k.Deck.Colors.Accent},
+		{"background", k.Deck.Colors.Background},
+		{"text", k.Deck.Colors.Text},
+	}
+	for _, c := range colors {
+		if c.value != "" && !colorPattern.MatchString(c.value) {
+			return nil, fmt.Errorf("invalid brand kit %s: deck.colors.%s is not a hex color or color name: %q", path, c.name, c.value)
+		}
+	}
+	for i, tag := range k.Hashtags {
+		k.Hashtags[i] = "#" + strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	}
+	return &k, nil
+}
+
+// Prompt returns the brand guidelines as an addition to a system prompt,
+// or "" if the kit sets none.
+func (k *Kit) Prompt() string {
+	var lines []string
+	if k.Company != "" {
+		lines = append(lines, fmt.Sprintf("- The company is %s; write its name exactly like that.", k.Company))
+	}
+	if len(k.Products) > 0 {
+		lines = append(lines, fmt.Sprintf("- Write product names with exactly this spelling and casing: %s.", strings.Join(k.Products, ", ")))
+	}
+	if len(k.Voice) > 0 {
+		lines = append(lines, fmt.Sprintf("- Voice and tone: %s.", strings.Join(k.Voice, ", ")))
+	}
+	if len(k.BannedWords) > 0 {
+		lines = append(lines, fmt.Sprintf("- Never use these words: %s.", strings.Join(k.BannedWords, ", ")))
+	}
+	if len(k.CTAs) > 0 {
+		lines = append(lines, fmt.Sprintf("- Where the format has a call to action, use one of these: %s.", quoteAll(k.CTAs)))
+	}
+	if len(k.Hashtags) > 0 {
+		lines = append(lines, fmt.Sprintf("- Where the format uses hashtags, include: %s.", strings.Join(k.Hashtags, " ")))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n\nBrand guidelines:\n\n" + strings.Join(lines, "\n")
+}
+
+func quoteAll(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return strings.Join(quoted, ", ")
+}
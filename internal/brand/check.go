@@ -0,0 +1,165 @@
+package brand
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Violation is a place where text breaks the brand kit's rules.
+type Violation struct {
+	Line    int // 1-based
+	Message string
+}
+
+// urlPattern matches URLs, which are skipped when checking names since
+// domains are lowercase.
+var urlPattern = regexp.MustCompile(`https?://\S+|\bwww\.\S+`)
+
+// wordPattern matches a word that may be a misspelled name.
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}.-]*[\p{L}\p{N}]`)
+
+// minFuzzyLength is the shortest name checked for misspellings; shorter
+// names have too many one-letter neighbours.
+const minFuzzyLength = 5
+
+// Check reports banned words, company and product names written with the
+// wrong casing, and words one letter away from a product name.
+// Hashtags, mentions and URLs are not checked for casing.
+func (k *Kit) Check(text string) []Violation {
+	names := k.names()
+
+	var violations []Violation
+	for i, line := range strings.Split(text, "\n") {
+		n := i + 1
+
+		for _, word := range k.BannedWords {
+			if len(findWord(line, word)) > 0 {
+				violations = append(violations, Violation{Line: n, Message: fmt.Sprintf("banned word %q", word)})
+			}
+		}
+
+		line = urlPattern.ReplaceAllStringFunc(line, func(url string) string {
+			return strings.Repeat(" ", len(url))
+		})
+		reported := map[string]bool{}
+		for _, name := range names {
+			for _, at := range findWord(line, name) {
+				found := line[at : at+len(name)]
+				if found == name || reported[found] || tagged(line, at) {
+					continue
+				}
+				reported[found] = true
+				violations = append(violations, Violation{Line: n, Message: fmt.Sprintf("%q should be written %q", found, name)})
+			}
+		}
+
+		for _, loc := range wordPattern.FindAllStringIndex(line, -1) {
+			word := line[loc[0]:loc[1]]
+			if reported[word] || tagged(line, loc[0]) {
+				continue
+			}
+			if name := k.misspelled(word); name != "" {
+				reported[word] = true
+				violations = append(violations, Violation{Line: n, Message: fmt.Sprintf("%q looks like a misspelling of %q", word, name)})
+			}
+		}
+	}
+	return violations
+}
+
+// names returns the company and product names.
+func (k *Kit) names() []string {
+	var names []string
+	if k.Company != "" {
+		names = append(names, k.Company)
+	}
+	return append(names, k.Products...)
+}
+
+// misspelled returns the product or company name that word is one edit
+// away from, ignoring case, or "".
+func (k *Kit) misspelled(word string) string {
+	lower := strings.ToLower(word)
+	for _, name := range k.names() {
+		target := strings.ToLower(name)
+		if utf8.RuneCountInString(target) < minFuzzyLength || lower == target {
+			continue
+		}
+		// Plurals and possessives are not misspellings.
+		if lower == target+"s" || lower == target+"'s" {
+			continue
+		}
+		if editDistance(lower, target) == 1 {
+			return name
+		}
+	}
+	return ""
+}
+
+// findWord returns the byte offsets of case-insensitive, whole-word
+// occurrences of word in line.
+func findWord(line, word string) []int {
+	if word == "" {
+		return nil
+	}
+	lowerLine, lowerWord := strings.ToLower(line), strings.ToLower(word)
+	if len(lowerLine) != len(line) {
+		// Case folding changed byte lengths; offsets would not line up.
+		lowerLine, lowerWord = line, word
+	}
+
+	var offsets []int
+	for start := 0; start < len(lowerLine); {
+		i := strings.Index(lowerLine[start:], lowerWord)
+		if i < 0 {
+			break
+		}
+		at := start + i
+		end := at + len(lowerWord)
+		before, _ := utf8.DecodeLastRuneInString(line[:at])
+		after, _ := utf8.DecodeRuneInString(line[end:])
+		if (at == 0 || !isWordRune(before)) && (end == len(line) || !isWordRune(after)) {
+			offsets = append(offsets, at)
+		}
+		start = end
+	}
+	return offsets
+}
+
+// tagged reports whether the word at offset is part of a hashtag or
+// mention.
+func tagged(line string, offset int) bool {
+	if offset == 0 {
+		return false
+	}
+	return line[offset-1] == '#' || line[offset-1] == '@'
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,56 @@
+package brand
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/theme"
+)
+
+// CSS returns theme CSS applying the deck colors and logo for a deck of
+// the given theme target, to be layered on top of the deck's theme. It
+// returns "" if the kit sets no deck styling.
+func (k *Kit) CSS(target string) string {
+	c := k.Deck.Colors
+	if c == (Colors{}) && k.Deck.Logo == "" {
+		return ""
+	}
+
+	slide, scope := "section", ""
+	if target == theme.TargetReveal {
+		slide, scope = ".reveal .slides section", ".reveal "
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "/* Brand kit: %s */\n", k.Company)
+	if target == theme.TargetReveal && c.Background != "" {
+		fmt.Fprintf(&b, "body {\n  background: %s;\n}\n", c.Background)
+	}
+
+	var decls []string
+	if c.Background != "" {
+		decls = append(decls, "background-color: "+c.Background)
+	}
+	if c.Text != "" {
+		decls = append(decls, "color: "+c.Text)
+	}
+	if k.Deck.Logo != "" {
+		decls = append(decls,
+			fmt.Sprintf("background-image: url(%q)", k.Deck.Logo),
+			"background-repeat: no-repeat",
+			"background-position: right 40px top 30px",
+			"background-size: 120px auto",
+		)
+	}
+	if len(decls) > 0 {
+		fmt.Fprintf(&b, "%s {\n  %s;\n}\n", slide, strings.Join(decls, ";\n  "))
+	}
+
+	if c.Primary != "" {
+		fmt.Fprintf(&b, "%sh1, %sh2, %sh3 {\n  color: %s;\n}\n", scope, scope, scope, c.Primary)
+	}
+	if c.Accent != "" {
+		fmt.Fprintf(&b, "%sa, %sstrong {\n  color: %s;\n}\n", scope, scope, c.Accent)
+	}
+	return b.String()
+}
@@ -26,7 +26,12 @@ func ParseFile(path string) (*Conversation, error) {
 		return ParseMarkdown(data)
 	}
 
-	// Auto-detect: try JSON, then Markdown
+	return Parse(data)
+}
+
+// Parse parses a conversation from data of unknown format, trying JSON first
+// and falling back to Markdown.
+func Parse(data []byte) (*Conversation, error) {
 	conv, err := ParseJSON(data)
 	if err == nil {
 		return conv, nil
@@ -50,6 +55,7 @@ func ParseJSON(data []byte) (*Conversation, error) {
 //   - **Assistant:** message
 //   - User: message
 //   - Assistant: message
+//   - **Alice (user):** message
 func ParseMarkdown(data []byte) (*Conversation, error) {
 	conv := &Conversation{
 		Messages: []Message{},
@@ -63,12 +69,15 @@ func ParseMarkdown(data []byte) (*Conversation, error) {
 	// Regex patterns for role detection
 	boldRolePattern := regexp.MustCompile(`^\*\*([Uu]ser|[Aa]ssistant|[Ss]ystem)\*\*:\s*(.*)$`)
 	simpleRolePattern := regexp.MustCompile(`^([Uu]ser|[Aa]ssistant|[Ss]ystem):\s*(.*)$`)
+	namedPattern := regexp.MustCompile(`^\*\*([^*():]+?)\s+\(([Uu]ser|[Aa]ssistant|[Ss]ystem)\)(?::\*\*|\*\*:)\s*(.*)$`)
 	titlePattern := regexp.MustCompile(`^#\s+(.+)$`)
+	var currentName string
 
 	flushMessage := func() {
 		if currentRole != "" && currentContent.Len() > 0 {
 			conv.Messages = append(conv.Messages, Message{
 				Role:    strings.ToLower(currentRole),
+				Name:    currentName,
 				Content: strings.TrimSpace(currentContent.String()),
 			})
 		}
@@ -89,7 +98,7 @@ func ParseMarkdown(data []byte) (*Conversation, error) {
 		// Check for bold role pattern
 		if matches := boldRolePattern.FindStringSubmatch(line); matches != nil {
 			flushMessage()
-			currentRole = matches[1]
+			currentRole, currentName = matches[1], ""
 			if matches[2] != "" {
 				currentContent.WriteString(matches[2])
 			}
@@ -99,13 +108,23 @@ func ParseMarkdown(data []byte) (*Conversation, error) {
 		// Check for simple role pattern
 		if matches := simpleRolePattern.FindStringSubmatch(line); matches != nil {
 			flushMessage()
-			currentRole = matches[1]
+			currentRole, currentName = matches[1], ""
 			if matches[2] != "" {
 				currentContent.WriteString(matches[2])
 			}
 			continue
 		}
 
+		// Check for named speaker pattern
+		if matches := namedPattern.FindStringSubmatch(line); matches != nil {
+			flushMessage()
+			currentRole, currentName = matches[2], strings.TrimSpace(matches[1])
+			if matches[3] != "" {
+				currentContent.WriteString(matches[3])
+			}
+			continue
+		}
+
 		// Continue building current message
 		if currentRole != "" {
 			if currentContent.Len() > 0 {
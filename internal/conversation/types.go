@@ -5,10 +5,20 @@ import "time"
 // Message represents a single message in a conversation.
 type Message struct {
 	Role      string    `json:"role"`
+	Name      string    `json:"name,omitempty"` // Speaker's name, when known
 	Content   string    `json:"content"`
 	Timestamp time.Time `json:"timestamp,omitempty"`
 }
 
+// Speaker returns the message's speaker: its name if set, otherwise its
+// role.
+func (m Message) Speaker() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return m.Role
+}
+
 // Conversation represents a complete conversation with metadata.
 type Conversation struct {
 	Title    string            `json:"title,omitempty"`
@@ -23,11 +33,29 @@ func (c *Conversation) ToPrompt() string {
 		result = "# " + c.Title + "\n\n"
 	}
 	for _, msg := range c.Messages {
-		result += "**" + msg.Role + ":** " + msg.Content + "\n\n"
+		speaker := msg.Role
+		if msg.Name != "" {
+			speaker = msg.Name + " (" + msg.Role + ")"
+		}
+		result += "**" + speaker + ":** " + msg.Content + "\n\n"
 	}
 	return result
 }
 
+// Speakers returns the names of the named speakers, in order of first
+// appearance.
+func (c *Conversation) Speakers() []string {
+	var speakers []string
+	seen := make(map[string]bool)
+	for _, msg := range c.Messages {
+		if msg.Name != "" && !seen[msg.Name] {
+			seen[msg.Name] = true
+			speakers = append(speakers, msg.Name)
+		}
+	}
+	return speakers
+}
+
 // Summary returns a brief summary of the conversation for context.
 func (c *Conversation) Summary() string {
 	if len(c.Messages) == 0 {
@@ -0,0 +1,211 @@
+package deck
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GlobalDirectives are the keys Marp accepts in frontmatter.
+var GlobalDirectives = map[string]bool{
+	"marp": true, "theme": true, "style": true, "headingDivider": true,
+	"lang": true, "size": true, "math": true, "title": true, "author": true,
+	"description": true, "keywords": true, "url": true, "image": true,
+	// Local directives may also be set globally in frontmatter.
+	"paginate": true, "header": true, "footer": true, "class": true,
+	"backgroundColor": true, "backgroundImage": true, "backgroundPosition": true,
+	"backgroundRepeat": true, "backgroundSize": true, "color": true,
+}
+
+// LocalDirectives are the keys Marp accepts in slide comments, with an
+// optional leading underscore to scope them to one slide.
+var LocalDirectives = map[string]bool{
+	"paginate": true, "header": true, "footer": true, "class": true,
+	"backgroundColor": true, "backgroundImage": true, "backgroundPosition": true,
+	"backgroundRepeat": true, "backgroundSize": true, "color": true,
+}
+
+// Issue kinds reported by Check.
+const (
+	IssueFrontmatter = "frontmatter"
+	IssueDirective   = "directive"
+	IssueSlideCount  = "slide_count"
+	IssueEmpty       = "empty"
+	IssueNotes       = "notes"
+	IssueOverflow    = "overflow"
+)
+
+// Issue is a problem found in a deck.
+type Issue struct {
+	Slide   int // 1-based slide number, 0 for the whole deck
+	Kind    string
+	Message string
+}
+
+// Limits bounds the size of a deck and its slides.
+type Limits struct {
+	MinSlides  int
+	MaxSlides  int
+	MaxBullets int // List items per slide
+	MaxLines   int // Non-blank content lines per slide, including code
+}
+
+// DefaultLimits suit Marp's default and gaia themes at 16:9.
+var DefaultLimits = Limits{MinSlides: 8, MaxSlides: 15, MaxBullets: 6, MaxLines: 12}
+
+// LimitsForTheme returns the limits for a Marp theme. The uncover theme
+// uses larger type, so fewer lines fit on a slide.
+func LimitsForTheme(theme string) Limits {
+	limits := DefaultLimits
+	if theme == "uncover" {
+		limits.MaxBullets = 5
+		limits.MaxLines = 10
+	}
+	return limits
+}
+
+// Theme returns the deck's theme directive, or "default".
+func (d *Deck) Theme() string {
+	if theme, ok := d.Directives["theme"].(string); ok && theme != "" {
+		return theme
+	}
+	return "default"
+}
+
+// bulletPattern matches an unordered or ordered list item.
+var bulletPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+
+// Check reports frontmatter and directive problems, a slide count outside
+// the limits, and slides that are empty, lack speaker notes, or overflow.
+// The title slide is not required to have notes.
+func (d *Deck) Check(limits Limits) []Issue {
+	var issues []Issue
+
+	switch {
+	case !d.HasFrontmatter:
+		issues = append(issues, Issue{Kind: IssueFrontmatter, Message: "missing frontmatter"})
+	case d.FrontmatterErr != nil:
+		issues = append(issues, Issue{Kind: IssueFrontmatter, Message: fmt.Sprintf("invalid frontmatter: %v", d.FrontmatterErr)})
+	default:
+		if marp, _ := d.Directives["marp"].(bool); !marp {
+			issues = append(issues, Issue{Kind: IssueFrontmatter, Message: "frontmatter must set marp: true"})
+		}
+		if paginate, ok := d.Directives["paginate"]; ok {
+			if _, isBool := paginate.(bool); !isBool {
+				issues = append(issues, Issue{Kind: IssueDirective, Message: fmt.Sprintf("paginate must be true or false, got %v", paginate)})
+			}
+		}
+		var unknown []string
+		for key := range d.Directives {
+			if !GlobalDirectives[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			issues = append(issues, Issue{Kind: IssueDirective, Message: fmt.Sprintf("unknown directive %q", key)})
+		}
+	}
+
+	if n := len(d.Slides); n < limits.MinSlides || n > limits.MaxSlides {
+		issues = append(issues, Issue{
+			Kind:    IssueSlideCount,
+			Message: fmt.Sprintf("%d slides, expected %d-%d", n, limits.MinSlides, limits.MaxSlides),
+		})
+	}
+
+	for i, s := range d.Slides {
+		issues = append(issues, s.Check(i == 0, limits)...)
+	}
+	return issues
+}
+
+// Check reports whether the slide is empty, lacks speaker notes, or
+// overflows. title marks the title slide, which needs no notes.
+func (s Slide) Check(title bool, limits Limits) []Issue {
+	body := s.Body()
+	if body == "" {
+		return []Issue{{Slide: s.Number, Kind: IssueEmpty, Message: "slide is empty"}}
+	}
+
+	var issues []Issue
+	if !title && len(s.Notes) == 0 {
+		issues = append(issues, Issue{Slide: s.Number, Kind: IssueNotes, Message: "no speaker notes"})
+	}
+
+	var bullets, lines int
+	prose := strings.Split(stripCode(body), "\n")
+	for i, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines++
+		if bulletPattern.MatchString(prose[i]) {
+			bullets++
+		}
+	}
+	if bullets > limits.MaxBullets {
+		issues = append(issues, Issue{
+			Slide:   s.Number,
+			Kind:    IssueOverflow,
+			Message: fmt.Sprintf("%d bullets, limit is %d", bullets, limits.MaxBullets),
+		})
+	} else if lines > limits.MaxLines {
+		issues = append(issues, Issue{
+			Slide:   s.Number,
+			Kind:    IssueOverflow,
+			Message: fmt.Sprintf("%d lines, limit is %d", lines, limits.MaxLines),
+		})
+	}
+	return issues
+}
+
+// marpLine matches the marp directive in frontmatter.
+var marpLine = regexp.MustCompile(`(?m)^marp:.*$`)
+
+// Repair makes the fixes that need no rewriting: it adds frontmatter with
+// marp: true and the given theme if it is missing, sets marp: true if it is
+// absent or false, and drops empty slides. It returns a description of each
+// change made.
+func (d *Deck) Repair(theme string) []Issue {
+	var changes []Issue
+
+	switch {
+	case !d.HasFrontmatter:
+		d.HasFrontmatter = true
+		d.Frontmatter = fmt.Sprintf("marp: true\ntheme: %s\npaginate: true", theme)
+		d.Directives = map[string]any{"marp": true, "theme": theme, "paginate": true}
+		changes = append(changes, Issue{Kind: IssueFrontmatter, Message: "added missing frontmatter"})
+	case d.FrontmatterErr == nil:
+		if marp, _ := d.Directives["marp"].(bool); !marp {
+			if marpLine.MatchString(d.Frontmatter) {
+				d.Frontmatter = marpLine.ReplaceAllString(d.Frontmatter, "marp: true")
+			} else {
+				d.Frontmatter = "marp: true\n" + d.Frontmatter
+			}
+			d.Directives["marp"] = true
+			changes = append(changes, Issue{Kind: IssueFrontmatter, Message: "set marp: true"})
+		}
+	}
+
+	var slides []Slide
+	for _, s := range d.Slides {
+		if s.Body() == "" {
+			changes = append(changes, Issue{Slide: s.Number, Kind: IssueEmpty, Message: "removed empty slide"})
+			continue
+		}
+		slides = append(slides, s)
+	}
+	d.Slides = slides
+	d.Renumber()
+
+	return changes
+}
+
+// Renumber numbers slides sequentially from 1.
+func (d *Deck) Renumber() {
+	for i := range d.Slides {
+		d.Slides[i].Number = i + 1
+	}
+}
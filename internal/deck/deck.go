@@ -0,0 +1,278 @@
+// Package deck parses and validates Markdown slide decks.
+package deck
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Deck is a parsed Marp or Reveal.js presentation.
+type Deck struct {
+	// Frontmatter is the raw YAML between the opening --- delimiters.
+	Frontmatter    string
+	HasFrontmatter bool
+	// Directives are the parsed frontmatter keys: Marp global directives,
+	// or reveal-md options for Reveal.js decks.
+	Directives map[string]any
+	// FrontmatterErr is set when the frontmatter is not valid YAML.
+	FrontmatterErr error
+
+	Slides []Slide
+}
+
+// Slide is a single slide in a deck.
+type Slide struct {
+	Number int
+	// Content is the slide's Markdown, including comments.
+	Content string
+	// Notes are the speaker notes, taken from HTML comments that are not
+	// directives.
+	Notes []string
+	// Directives are local directives set in HTML comments. Scoped
+	// directives keep their leading underscore.
+	Directives map[string]string
+	// Vertical marks a Reveal.js slide stacked below the previous one.
+	Vertical bool
+
+	body string
+}
+
+// commentPattern matches an HTML comment.
+var commentPattern = regexp.MustCompile(`(?s)<!--(.*?)-->`)
+
+// directivePattern matches a "key: value" line in a directive comment.
+var directivePattern = regexp.MustCompile(`^\s*(_?[A-Za-z]+)\s*:\s*(.*?)\s*$`)
+
+// ParseMarp parses a Marp Markdown document. A code fence wrapping the whole
+// document is removed. Slides are split on --- lines outside code fences.
+// Parsing never fails; problems are reported by Check.
+func ParseMarp(text string) *Deck {
+	d := &Deck{Directives: map[string]any{}}
+	lines := d.parseFrontmatter(strings.Split(strings.TrimSpace(UnwrapFence(text)), "\n"))
+	for i, content := range splitSlides(lines, "---", "") {
+		d.Slides = append(d.Slides, NewSlide(i+1, content.text))
+	}
+	return d
+}
+
+// ParseReveal parses a Reveal.js Markdown document. Frontmatter in the
+// reveal-md style (theme, revealOptions) is parsed as for Marp. Slides are
+// split on --- (horizontal) and -- (vertical) lines outside code fences,
+// and everything after a "Note:" line is taken as speaker notes.
+func ParseReveal(text string) *Deck {
+	d := &Deck{Directives: map[string]any{}}
+	lines := d.parseFrontmatter(strings.Split(strings.TrimSpace(UnwrapFence(text)), "\n"))
+	for i, content := range splitSlides(lines, "---", "--") {
+		s := newRevealSlide(i+1, content.text)
+		s.Vertical = content.vertical
+		d.Slides = append(d.Slides, s)
+	}
+	return d
+}
+
+// parseFrontmatter reads YAML frontmatter between --- lines at the start of
+// lines into the deck and returns the remaining lines.
+func (d *Deck) parseFrontmatter(lines []string) []string {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return lines
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			d.HasFrontmatter = true
+			d.Frontmatter = strings.Join(lines[1:i], "\n")
+			lines = lines[i+1:]
+			break
+		}
+	}
+	if d.HasFrontmatter {
+		if err := yaml.Unmarshal([]byte(d.Frontmatter), &d.Directives); err != nil {
+			d.FrontmatterErr = err
+		}
+		if d.Directives == nil {
+			d.Directives = map[string]any{}
+		}
+	}
+	return lines
+}
+
+// slideText is the Markdown of one slide and whether it was separated from
+// the previous slide by the vertical separator.
+type slideText struct {
+	text     string
+	vertical bool
+}
+
+// splitSlides splits lines into slides on separator lines that are not
+// inside a code fence. vertical may be empty.
+func splitSlides(lines []string, horizontal, vertical string) []slideText {
+	var (
+		slides  []slideText
+		current []string
+		next    bool
+		code    fence
+	)
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !code.update(line) && !code.open() && (trimmed == horizontal || vertical != "" && trimmed == vertical) {
+			slides = append(slides, slideText{text: strings.TrimSpace(strings.Join(current, "\n")), vertical: next})
+			current = nil
+			next = trimmed == vertical
+			continue
+		}
+		current = append(current, line)
+	}
+	return append(slides, slideText{text: strings.TrimSpace(strings.Join(current, "\n")), vertical: next})
+}
+
+// fence tracks whether a line-by-line scan is inside a fenced code block.
+type fence struct {
+	marker string // The ``` or ~~~ run that opened the block
+}
+
+// update advances the scan past line and reports whether it opened or
+// closed a code block.
+func (f *fence) update(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, c := range []string{"`", "~"} {
+		if !strings.HasPrefix(trimmed, c+c+c) {
+			continue
+		}
+		run := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, c))]
+		switch {
+		case f.marker == "":
+			f.marker = run
+			return true
+		case run == trimmed && strings.HasPrefix(run, f.marker):
+			f.marker = ""
+			return true
+		}
+	}
+	return false
+}
+
+// open reports whether the scan is inside a code block.
+func (f *fence) open() bool {
+	return f.marker != ""
+}
+
+// UnwrapFence removes a code fence around the whole document, which models
+// sometimes add when asked for Markdown.
+func UnwrapFence(text string) string {
+	trimmed := strings.TrimSpace(text)
+	first, rest, ok := strings.Cut(trimmed, "\n")
+	if !ok || !strings.HasPrefix(first, "```") {
+		return text
+	}
+	if body, found := strings.CutSuffix(strings.TrimRight(rest, " \t\n"), "```"); found {
+		return body
+	}
+	return text
+}
+
+// NewSlide parses a slide's Markdown, separating directive comments from
+// speaker notes.
+func NewSlide(number int, content string) Slide {
+	s := Slide{Number: number, Content: strings.TrimSpace(content), Directives: map[string]string{}}
+	s.body = strings.TrimSpace(commentPattern.ReplaceAllString(s.Content, ""))
+	for _, m := range commentPattern.FindAllStringSubmatch(stripCode(s.Content), -1) {
+		if directives, ok := parseDirectives(m[1]); ok {
+			for k, v := range directives {
+				s.Directives[k] = v
+			}
+			continue
+		}
+		if note := strings.TrimSpace(m[1]); note != "" {
+			s.Notes = append(s.Notes, note)
+		}
+	}
+	return s
+}
+
+// notesPattern matches the line that starts Reveal.js speaker notes.
+var notesPattern = regexp.MustCompile(`^\s*Notes?:\s*(.*)$`)
+
+// newRevealSlide parses a Reveal.js slide, splitting off speaker notes.
+func newRevealSlide(number int, content string) Slide {
+	s := Slide{Number: number, Content: strings.TrimSpace(content), Directives: map[string]string{}}
+	lines := strings.Split(s.Content, "\n")
+	prose := strings.Split(stripCode(s.Content), "\n")
+	for i := range lines {
+		m := notesPattern.FindStringSubmatch(prose[i])
+		if m == nil {
+			continue
+		}
+		note := strings.TrimSpace(strings.Join(append([]string{m[1]}, lines[i+1:]...), "\n"))
+		if note != "" {
+			s.Notes = []string{note}
+		}
+		s.body = strings.TrimSpace(strings.Join(lines[:i], "\n"))
+		return s
+	}
+	s.body = s.Content
+	return s
+}
+
+// parseDirectives returns the directives in a comment if every line is a
+// "key: value" pair naming a known directive.
+func parseDirectives(comment string) (map[string]string, bool) {
+	directives := map[string]string{}
+	for _, line := range strings.Split(comment, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		m := directivePattern.FindStringSubmatch(line)
+		if m == nil || !LocalDirectives[strings.TrimPrefix(m[1], "_")] {
+			return nil, false
+		}
+		directives[m[1]] = m[2]
+	}
+	return directives, len(directives) > 0
+}
+
+// Body returns the slide's Markdown without speaker notes or directive
+// comments.
+func (s Slide) Body() string {
+	return s.body
+}
+
+// stripCode blanks out fenced code blocks so their contents are not
+// mistaken for comments or list items.
+func stripCode(content string) string {
+	var (
+		out  []string
+		code fence
+	)
+	for _, line := range strings.Split(content, "\n") {
+		if code.update(line) || code.open() {
+			out = append(out, "")
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// Render formats the deck as Markdown. Vertical slides are separated with
+// --, as in Reveal.js.
+func (d *Deck) Render() string {
+	var b strings.Builder
+	if d.HasFrontmatter {
+		b.WriteString("---\n")
+		b.WriteString(strings.TrimRight(d.Frontmatter, "\n"))
+		b.WriteString("\n---\n\n")
+	}
+	for i, s := range d.Slides {
+		switch {
+		case i == 0:
+		case s.Vertical:
+			b.WriteString("\n\n--\n\n")
+		default:
+			b.WriteString("\n\n---\n\n")
+		}
+		b.WriteString(s.Content)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -82,6 +83,63 @@ func (c *Client) Generate(ctx context.Context, systemPrompt, userPrompt string)
 	return result, nil
 }
 
+// Schema describes the JSON object expected from GenerateJSON.
+type Schema struct {
+	Name        string         // Tool name the model calls to return the object
+	Description string         // What the object represents and how to fill it
+	Properties  map[string]any // JSON Schema properties of the object
+	Required    []string       // Required property names
+}
+
+// GenerateJSON asks Claude for an object matching schema and decodes it into
+// out. The model is forced to answer through a tool call whose input schema
+// is the requested shape, so the response is always a JSON object.
+func (c *Client) GenerateJSON(ctx context.Context, systemPrompt, userPrompt string, schema Schema, out any) error {
+	tool := anthropic.ToolParam{
+		Name:        schema.Name,
+		Description: anthropic.String(schema.Description),
+		InputSchema: anthropic.ToolInputSchemaParam{
+			Properties: schema.Properties,
+			Required:   schema.Required,
+		},
+	}
+
+	params := anthropic.MessageNewParams{
+		Model:     anthropic.Model(c.config.Model),
+		MaxTokens: int64(c.config.MaxTokens),
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock(userPrompt)),
+		},
+		Tools:      []anthropic.ToolUnionParam{{OfTool: &tool}},
+		ToolChoice: anthropic.ToolChoiceParamOfTool(schema.Name),
+	}
+
+	if systemPrompt != "" {
+		params.System = []anthropic.TextBlockParam{
+			{
+				Type: "text",
+				Text: systemPrompt,
+			},
+		}
+	}
+
+	message, err := c.client.Messages.New(ctx, params)
+	if err != nil {
+		return fmt.Errorf("claude API error: %w", err)
+	}
+
+	for _, block := range message.Content {
+		if block.Type == "tool_use" && block.Name == schema.Name {
+			if err := json.Unmarshal(block.Input, out); err != nil {
+				return fmt.Errorf("failed to decode %s output: %w", schema.Name, err)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("claude returned no %s output (stop reason: %s)", schema.Name, message.StopReason)
+}
+
 // GenerateWithRetry attempts generation with retries on failure.
 func (c *Client) GenerateWithRetry(ctx context.Context, systemPrompt, userPrompt string, maxRetries int) (string, error) {
 	var lastErr error
@@ -0,0 +1,508 @@
+// Package mcp exposes the content agents as Model Context Protocol tools
+// over a newline-delimited JSON-RPC stream such as stdio.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/agentplexus/agent-team-content/internal/agent"
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/theme"
+)
+
+// Resource URIs.
+const (
+	agentsURI      = "content://agents"
+	themesURI      = "content://themes"
+	themeURIPrefix = "content://themes/"
+)
+
+// Config holds configuration for the MCP server.
+type Config struct {
+	Name      string        // Server name reported to clients
+	Version   string        // Server version reported to clients
+	ThemesDir string        // Directory of theme CSS files exposed as resources
+	Root      string        // Directory conversation files named by path must be in ("" disables path)
+	Options   agent.Options // Default agent options
+}
+
+// Server answers MCP requests by running content agents.
+type Server struct {
+	client *llm.Client
+	config Config
+
+	writeMu sync.Mutex
+	out     *json.Encoder
+
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+}
+
+// New creates an MCP server that generates content with the given client.
+func New(client *llm.Client, cfg Config) *Server {
+	return &Server{
+		client:   client,
+		config:   cfg,
+		inflight: make(map[string]context.CancelFunc),
+	}
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is canceled. initialize is answered before the next request is
+// read; other requests run concurrently, so their responses may be written
+// out of order, matched by request ID.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = json.NewEncoder(w)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	lines := make(chan []byte)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-scanErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			if len(strings.TrimSpace(string(line))) == 0 {
+				continue
+			}
+
+			var req request
+			if err := json.Unmarshal(line, &req); err != nil {
+				s.reply(nil, nil, &rpcError{Code: codeParseError, Message: "parse error"})
+				continue
+			}
+			if req.JSONRPC != "2.0" || req.Method == "" {
+				if !req.isNotification() {
+					s.reply(req.ID, nil, &rpcError{Code: codeInvalidRequest, Message: "invalid request"})
+				}
+				continue
+			}
+
+			if req.Method == "initialize" {
+				s.handle(ctx, &req)
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.handle(ctx, &req)
+			}()
+		}
+	}
+}
+
+// handle dispatches a single request and writes its response.
+func (s *Server) handle(ctx context.Context, req *request) {
+	if req.isNotification() {
+		if req.Method == "notifications/cancelled" {
+			var params cancelledParams
+			if err := json.Unmarshal(req.Params, &params); err == nil {
+				s.cancel(string(params.RequestID))
+			}
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.track(string(req.ID), cancel)
+	defer s.untrack(string(req.ID))
+
+	var (
+		result any
+		err    error
+	)
+	switch req.Method {
+	case "initialize":
+		result, err = s.initialize(req.Params)
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		result = s.listTools()
+	case "tools/call":
+		result, err = s.callTool(ctx, req.Params)
+	case "resources/list":
+		result, err = s.listResources()
+	case "resources/read":
+		result, err = s.readResource(req.Params)
+	default:
+		err = &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	if err != nil {
+		rerr, ok := err.(*rpcError)
+		if !ok {
+			rerr = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		s.reply(req.ID, nil, rerr)
+		return
+	}
+	s.reply(req.ID, result, nil)
+}
+
+func (s *Server) reply(id json.RawMessage, result any, rerr *rpcError) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.out.Encode(response{JSONRPC: "2.0", ID: id, Result: result, Error: rerr}); err != nil {
+		fmt.Fprintf(os.Stderr, "mcp: failed to write response: %v\n", err)
+	}
+}
+
+func (s *Server) track(id string, cancel context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inflight[id] = cancel
+}
+
+func (s *Server) untrack(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inflight, id)
+}
+
+func (s *Server) cancel(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cancel, ok := s.inflight[id]; ok {
+		cancel()
+	}
+}
+
+func (s *Server) initialize(raw json.RawMessage) (any, error) {
+	var params initializeParams
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+	}
+
+	version := protocolVersion
+	if supportedVersions[params.ProtocolVersion] {
+		version = params.ProtocolVersion
+	}
+
+	return initializeResult{
+		ProtocolVersion: version,
+		Capabilities: map[string]any{
+			"tools":     map[string]any{},
+			"resources": map[string]any{},
+		},
+		ServerInfo: serverInfo{Name: s.config.Name, Version: s.config.Version},
+	}, nil
+}
+
+// listTools returns one tool per registered agent.
+func (s *Server) listTools() listToolsResult {
+	var tools []tool
+	for _, name := range agent.ListAgents() {
+		properties := map[string]any{
+			"conversation": map[string]any{
+				"type":        "string",
+				"description": "Conversation text in JSON or Markdown (User:/Assistant: lines)",
+			},
+			"path": map[string]any{
+				"type":        "string",
+				"description": "Path to a conversation file (.json or .md) in the server's root directory, used when conversation is empty",
+			},
+		}
+		if name == "marp" {
+			properties["marp_theme"] = map[string]any{
+				"type":        "string",
+				"description": "Marp theme name (see " + themesURI + ")",
+			}
+		}
+		if name == "revealjs" {
+			properties["revealjs_theme"] = map[string]any{
+				"type":        "string",
+				"description": "Reveal.js theme name (see " + themesURI + ")",
+			}
+		}
+
+		tools = append(tools, tool{
+			Name:        name,
+			Description: agent.Describe(name) + ". Returns the generated Markdown.",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": properties,
+			},
+		})
+	}
+	return listToolsResult{Tools: tools}
+}
+
+// callTool runs a single agent. Agent failures are reported as tool errors
+// rather than protocol errors so the assistant can see them.
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params callToolParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	conv, err := s.loadConversation(params.Arguments)
+	if err != nil {
+		return toolError(err), nil
+	}
+
+	opts := s.config.Options
+	if err := params.Arguments.validateThemes(opts.ThemesDir); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	if params.Arguments.MarpTheme != "" {
+		opts.MarpTheme = params.Arguments.MarpTheme
+	}
+	if params.Arguments.RevealTheme != "" {
+		opts.RevealTheme = params.Arguments.RevealTheme
+	}
+
+	orchestrator, err := agent.NewOrchestratorWithAgents(s.client, []string{params.Name}, opts)
+	if err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	results := orchestrator.Generate(ctx, conv)
+	if len(results) == 0 {
+		return toolError(fmt.Errorf("agent %s produced no result", params.Name)), nil
+	}
+
+	result := results[0]
+	if result.Error != nil {
+		return toolError(result.Error), nil
+	}
+
+	content := []textContent{{Type: "text", Text: result.Content}}
+	for _, artifact := range result.Artifacts {
+		content = append(content, textContent{Type: "text", Text: artifact.File + ":\n" + artifact.Content})
+	}
+	if len(result.Diagnostics) > 0 {
+		lines := []string{"Diagnostics:"}
+		for _, d := range result.Diagnostics {
+			lines = append(lines, fmt.Sprintf("- [%s] %s", d.Severity, d))
+		}
+		content = append(content, textContent{Type: "text", Text: strings.Join(lines, "\n")})
+	}
+	return callToolResult{Content: content}, nil
+}
+
+// loadConversation parses the conversation text, or the file at path when no
+// text is given.
+func (s *Server) loadConversation(in toolInput) (*conversation.Conversation, error) {
+	var (
+		conv *conversation.Conversation
+		err  error
+	)
+	switch {
+	case strings.TrimSpace(in.Conversation) != "":
+		conv, err = conversation.Parse([]byte(in.Conversation))
+	case in.Path != "":
+		path, rerr := s.resolvePath(in.Path)
+		if rerr != nil {
+			return nil, rerr
+		}
+		conv, err = conversation.ParseFile(path)
+	default:
+		return nil, fmt.Errorf("conversation or path is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse conversation: %w", err)
+	}
+	if len(conv.Messages) == 0 {
+		return nil, fmt.Errorf("conversation has no messages")
+	}
+	return conv, nil
+}
+
+// resolvePath returns the conversation file named by path, relative to the
+// root directory. Paths outside the root, including through symlinks, are
+// rejected with the same error whether or not they exist.
+func (s *Server) resolvePath(path string) (string, error) {
+	errOutside := fmt.Errorf("path %q is not a file in the server's root directory", path)
+	if s.config.Root == "" {
+		return "", fmt.Errorf("path is not enabled on this server; pass the conversation text instead")
+	}
+	root, err := filepath.EvalSymlinks(s.config.Root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root directory: %w", err)
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return "", fmt.Errorf("failed to resolve root directory: %w", err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errOutside
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errOutside
+	}
+	return resolved, nil
+}
+
+// validateThemes rejects themes that are not registered theme names.
+// Clients may only choose registered themes, never files on the server.
+func (in toolInput) validateThemes(themesDir string) error {
+	if in.MarpTheme == "" && in.RevealTheme == "" {
+		return nil
+	}
+	registry, err := theme.Discover(themesDir)
+	if err != nil {
+		return err
+	}
+	if in.MarpTheme != "" {
+		if _, err := registry.ResolveName(theme.TargetMarp, in.MarpTheme); err != nil {
+			return err
+		}
+	}
+	if in.RevealTheme != "" {
+		if _, err := registry.ResolveName(theme.TargetReveal, in.RevealTheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toolError(err error) callToolResult {
+	return callToolResult{
+		Content: []textContent{{Type: "text", Text: err.Error()}},
+		IsError: true,
+	}
+}
+
+// listResources returns the agent list, the theme list, and each theme file.
+func (s *Server) listResources() (any, error) {
+	resources := []resource{
+		{URI: agentsURI, Name: "agents", Description: "Available content agents", MimeType: "application/json"},
+		{URI: themesURI, Name: "themes", Description: "Available presentation themes", MimeType: "application/json"},
+	}
+
+	themes, err := s.themeFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range themes {
+		resources = append(resources, resource{
+			URI:      themeURIPrefix + file,
+			Name:     file,
+			MimeType: "text/css",
+		})
+	}
+
+	return listResourcesResult{Resources: resources}, nil
+}
+
+func (s *Server) readResource(raw json.RawMessage) (any, error) {
+	var params readResourceParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	var contents resourceContents
+	switch {
+	case params.URI == agentsURI:
+		type agentInfo struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		}
+		var agents []agentInfo
+		for _, name := range agent.ListAgents() {
+			agents = append(agents, agentInfo{Name: name, Description: agent.Describe(name)})
+		}
+		data, _ := json.MarshalIndent(agents, "", "  ")
+		contents = resourceContents{URI: params.URI, MimeType: "application/json", Text: string(data)}
+
+	case params.URI == themesURI:
+		themes, err := s.themeFiles()
+		if err != nil {
+			return nil, err
+		}
+		data, _ := json.MarshalIndent(themes, "", "  ")
+		contents = resourceContents{URI: params.URI, MimeType: "application/json", Text: string(data)}
+
+	case strings.HasPrefix(params.URI, themeURIPrefix):
+		file := strings.TrimPrefix(params.URI, themeURIPrefix)
+		themes, err := s.themeFiles()
+		if err != nil {
+			return nil, err
+		}
+		idx := sort.SearchStrings(themes, file)
+		if idx == len(themes) || themes[idx] != file {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "unknown resource: " + params.URI}
+		}
+		data, err := os.ReadFile(filepath.Join(s.config.ThemesDir, file))
+		if err != nil {
+			return nil, err
+		}
+		contents = resourceContents{URI: params.URI, MimeType: "text/css", Text: string(data)}
+
+	default:
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown resource: " + params.URI}
+	}
+
+	return readResourceResult{Contents: []resourceContents{contents}}, nil
+}
+
+// themeFiles returns the sorted CSS file names in the themes directory.
+func (s *Server) themeFiles() ([]string, error) {
+	if s.config.ThemesDir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(s.config.ThemesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read themes directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".css") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,85 @@
+package mermaid
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// classNamePattern matches a class name, with optional ~T~ generics.
+const classNamePattern = `[A-Za-z_][\w.]*(?:~[^~]+~)?`
+
+var (
+	classDeclPattern   = regexp.MustCompile(`^class\s+` + classNamePattern + `(?:\["[^"]*"\])?(?:\s*:::\w+)?\s*(\{)?\s*(\})?$`)
+	classMemberPattern = regexp.MustCompile(`^` + classNamePattern + `\s*:\s*\S.*$`)
+	annotationPattern  = regexp.MustCompile(`^<<[^<>]+>>(?:\s+` + classNamePattern + `)?$`)
+	namespacePattern   = regexp.MustCompile(`^namespace\s+[\w.]+\s*\{$`)
+	classNotePattern   = regexp.MustCompile(`^note\s+(?:for\s+` + classNamePattern + `\s+)?"[^"]*"$`)
+
+	// relationPattern matches a relationship such as
+	// `Animal "1" <|-- "*" Dog : has`: an optional end marker, a solid or
+	// dashed line, another optional end marker, and optional cardinalities
+	// and label.
+	relationPattern = regexp.MustCompile(`^` + classNamePattern + `\s*(?:"[^"]*"\s*)?(?:<\||\*|o|<)?(?:--|\.\.)(?:\|>|\*|o|>)?\s*(?:"[^"]*"\s*)?` + classNamePattern + `\s*(?::\s*.*)?$`)
+
+	// relationLinePattern detects a statement that tries to be a
+	// relationship.
+	relationLinePattern = regexp.MustCompile(`--|\.\.`)
+)
+
+// classKeywords are styling and interaction statements.
+var classKeywords = map[string]bool{
+	"direction": true,
+	"classDef":  true,
+	"cssClass":  true,
+	"style":     true,
+	"click":     true,
+	"link":      true,
+	"callback":  true,
+}
+
+func checkClass(lines []line) []Error {
+	var (
+		b       blocks
+		members bool // inside a class body, where each line is a member
+	)
+	for _, l := range lines {
+		first := strings.Fields(l.text)[0]
+		switch {
+		case l.text == "}":
+			members = false
+			b.pop(l)
+		case members:
+			if strings.Contains(l.text, "{") || strings.Contains(l.text, "}") {
+				b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("unexpected brace in member %q", l.text)})
+			}
+		case first == "class":
+			m := classDeclPattern.FindStringSubmatch(l.text)
+			switch {
+			case m == nil:
+				b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("invalid class declaration %q", l.text)})
+			case m[1] != "" && m[2] == "":
+				members = true
+				b.push(l)
+			}
+		case first == "namespace":
+			if !namespacePattern.MatchString(l.text) {
+				b.errs = append(b.errs, Error{Line: l.n, Message: `namespace must read "namespace <name> {"`})
+			}
+			b.push(l)
+		case classKeywords[first], annotationPattern.MatchString(l.text):
+		case first == "note":
+			if !classNotePattern.MatchString(l.text) {
+				b.errs = append(b.errs, Error{Line: l.n, Message: `note must read "note for <class> \"text\""`})
+			}
+		case relationLinePattern.MatchString(strings.SplitN(l.text, ":", 2)[0]):
+			if !relationPattern.MatchString(l.text) {
+				b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("invalid relationship %q", l.text)})
+			}
+		case classMemberPattern.MatchString(l.text):
+		default:
+			b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("unrecognized statement %q", l.text)})
+		}
+	}
+	return b.unclosed("}")
+}
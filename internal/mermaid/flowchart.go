@@ -0,0 +1,216 @@
+package mermaid
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// nodeIDPattern matches a node ID. Hyphens are allowed between words
+	// so that "a-b-->c" reads as node "a-b" and an arrow.
+	nodeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(?:[-.][A-Za-z0-9_]+)*`)
+
+	// linkPattern matches a link without inline text: ---, -->, -.->,
+	// ==>, ~~~ and their longer, bidirectional and o/x-ended variants,
+	// such as ---> and ===>.
+	linkPattern = regexp.MustCompile(`^[<ox]?(?:-{2,}[>ox]?|={2,}[>ox]?|-\.+-[>ox]?|~{3,})`)
+
+	// textLinkPattern matches a link with inline text, e.g. "-- yes -->".
+	textLinkPattern = regexp.MustCompile(`^[<ox]?(?:--|==|-\.)\s+[^|]+?\s+(?:-{2,}[>ox]?|={2,}[>ox]?|\.+-[>ox]?)`)
+
+	// linkLabelPattern matches a link label, e.g. "|yes|".
+	linkLabelPattern = regexp.MustCompile(`^\|[^|]*\|`)
+
+	// nodeClassPattern matches a ":::className" suffix.
+	nodeClassPattern = regexp.MustCompile(`^:::[A-Za-z0-9_-]+`)
+
+	flowchartDirectionPattern = regexp.MustCompile(`^direction\s+(?:TB|TD|BT|RL|LR)$`)
+)
+
+// nodeShapes are the node shape delimiters, longest opening first.
+var nodeShapes = []struct {
+	open   string
+	closes []string
+}{
+	{"(((", []string{")))"}},
+	{"((", []string{"))"}},
+	{"([", []string{"])"}},
+	{"[[", []string{"]]"}},
+	{"[(", []string{")]"}},
+	{"[/", []string{"/]", `\]`}},
+	{`[\`, []string{`\]`, "/]"}},
+	{"{{", []string{"}}"}},
+	{"[", []string{"]"}},
+	{"(", []string{")"}},
+	{"{", []string{"}"}},
+	{">", []string{"]"}},
+}
+
+// flowchartKeywords are statements that do not describe nodes and links.
+var flowchartKeywords = map[string]bool{
+	"classDef":  true,
+	"class":     true,
+	"style":     true,
+	"linkStyle": true,
+	"click":     true,
+}
+
+func checkFlowchart(lines []line) []Error {
+	var b blocks
+	for _, l := range lines {
+		for _, text := range splitStatements(l.text) {
+			stmt := line{n: l.n, text: text}
+			fields := strings.Fields(text)
+			switch {
+			case fields[0] == "subgraph":
+				if len(fields) == 1 {
+					b.errs = append(b.errs, Error{Line: l.n, Message: "subgraph needs an ID or title"})
+				}
+				b.push(stmt)
+			case text == "end":
+				b.pop(stmt)
+			case fields[0] == "direction":
+				if !flowchartDirectionPattern.MatchString(text) {
+					b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("invalid direction %q, expected TB, TD, BT, RL or LR", text)})
+				}
+			case flowchartKeywords[fields[0]]:
+				if len(fields) < 3 && fields[0] != "click" || len(fields) < 2 {
+					b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("incomplete %s statement", fields[0])})
+				}
+			default:
+				if msg := checkChain(text); msg != "" {
+					b.errs = append(b.errs, Error{Line: l.n, Message: msg})
+				}
+			}
+		}
+	}
+	return b.unclosed("end")
+}
+
+// splitStatements splits a line at semicolons outside quotes and node
+// text.
+func splitStatements(text string) []string {
+	var (
+		stmts []string
+		depth int
+		quote bool
+		start int
+	)
+	for i, r := range text {
+		switch {
+		case r == '"':
+			quote = !quote
+		case quote:
+		case strings.ContainsRune("[({", r):
+			depth++
+		case strings.ContainsRune("])}", r) && depth > 0:
+			depth--
+		case r == ';' && depth == 0:
+			if s := strings.TrimSpace(text[start:i]); s != "" {
+				stmts = append(stmts, s)
+			}
+			start = i + 1
+		}
+	}
+	if s := strings.TrimSpace(text[start:]); s != "" {
+		stmts = append(stmts, s)
+	}
+	return stmts
+}
+
+// checkChain checks a statement of nodes joined by links, such as
+// "A[Start] --> B{Ready?} -->|yes| C & D", and describes the first problem.
+func checkChain(text string) string {
+	rest := text
+	for {
+		for {
+			var msg string
+			if rest, msg = parseNode(rest); msg != "" {
+				return msg
+			}
+			rest = strings.TrimSpace(rest)
+			if !strings.HasPrefix(rest, "&") {
+				break
+			}
+			rest = strings.TrimSpace(rest[1:])
+		}
+		if rest == "" {
+			return ""
+		}
+
+		link := textLinkPattern.FindString(rest)
+		if link == "" {
+			link = linkPattern.FindString(rest)
+			if link == "" {
+				return fmt.Sprintf("expected a link such as --> before %q", rest)
+			}
+			if label := linkLabelPattern.FindString(strings.TrimSpace(rest[len(link):])); label != "" {
+				link = rest[:strings.Index(rest, label)+len(label)]
+			}
+		}
+		rest = strings.TrimSpace(rest[len(link):])
+		if rest == "" {
+			return fmt.Sprintf("link %q has no target node", strings.TrimSpace(link))
+		}
+	}
+}
+
+// parseNode parses a node ID with an optional shape and class at the start
+// of text, returning the rest of text or a description of the problem.
+func parseNode(text string) (string, string) {
+	id := nodeIDPattern.FindString(text)
+	if id == "" {
+		return "", fmt.Sprintf("expected a node ID at %q", text)
+	}
+	if id == "end" {
+		return "", `"end" is reserved and cannot be a node ID; use "End" or another name`
+	}
+	rest := text[len(id):]
+
+	for _, shape := range nodeShapes {
+		if !strings.HasPrefix(rest, shape.open) {
+			continue
+		}
+		body := rest[len(shape.open):]
+		label, after, ok := shapeText(body, shape.closes)
+		if !ok {
+			return "", fmt.Sprintf("node %s opens %q but never closes it", id, shape.open)
+		}
+		if !strings.HasPrefix(label, `"`) && strings.ContainsAny(label, `[](){}"`) {
+			return "", fmt.Sprintf("node %s text %q contains brackets or quotes; wrap the text in double quotes", id, label)
+		}
+		rest = after
+		break
+	}
+
+	if class := nodeClassPattern.FindString(rest); class != "" {
+		rest = rest[len(class):]
+	}
+	return rest, ""
+}
+
+// shapeText splits body at the first of closes outside double quotes,
+// returning the node text and what follows the closing delimiter.
+func shapeText(body string, closes []string) (string, string, bool) {
+	start := 0
+	if strings.HasPrefix(body, `"`) {
+		end := strings.Index(body[1:], `"`)
+		if end < 0 {
+			return "", "", false
+		}
+		start = end + 2
+	}
+	best := -1
+	var closer string
+	for _, c := range closes {
+		if i := strings.Index(body[start:], c); i >= 0 && (best < 0 || i < best) {
+			best, closer = i, c
+		}
+	}
+	if best < 0 {
+		return "", "", false
+	}
+	end := start + best
+	return body[:end], body[end+len(closer):], true
+}
@@ -0,0 +1,149 @@
+// Package mermaid checks the syntax of Mermaid diagrams. Flowcharts,
+// sequence diagrams and class diagrams are supported; the checks cover the
+// mistakes that make Mermaid refuse to render a diagram, not the full
+// grammar.
+package mermaid
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Supported diagram types.
+const (
+	Flowchart = "flowchart"
+	Sequence  = "sequence"
+	Class     = "class"
+)
+
+// Types are the supported diagram types.
+var Types = []string{Flowchart, Sequence, Class}
+
+// Error is a syntax error in a diagram.
+type Error struct {
+	Line    int // 1-based line in the diagram source
+	Message string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// line is a statement with its source line number.
+type line struct {
+	n    int
+	text string
+}
+
+// headerPatterns match the first statement of each supported type.
+var headerPatterns = map[string]*regexp.Regexp{
+	Flowchart: regexp.MustCompile(`^(?:flowchart|graph)(?:\s+(TB|TD|BT|RL|LR))?\s*;?$`),
+	Sequence:  regexp.MustCompile(`^sequenceDiagram\s*$`),
+	Class:     regexp.MustCompile(`^classDiagram(?:-v2)?\s*$`),
+}
+
+// Type returns the supported type declared by the diagram's first
+// statement, or "".
+func Type(src string) string {
+	lines := statements(src)
+	if len(lines) == 0 {
+		return ""
+	}
+	first := strings.Fields(lines[0].text)[0]
+	switch first {
+	case "flowchart", "graph":
+		return Flowchart
+	case "sequenceDiagram":
+		return Sequence
+	case "classDiagram", "classDiagram-v2":
+		return Class
+	}
+	return ""
+}
+
+// Check returns the syntax errors in a diagram, which must be one of the
+// supported types.
+func Check(src string) []Error {
+	lines := statements(src)
+	if len(lines) == 0 {
+		return []Error{{Line: 1, Message: "empty diagram"}}
+	}
+
+	kind := Type(src)
+	if kind == "" {
+		return []Error{{Line: lines[0].n, Message: fmt.Sprintf("unsupported diagram type %q, expected flowchart, sequenceDiagram or classDiagram", strings.Fields(lines[0].text)[0])}}
+	}
+	if !headerPatterns[kind].MatchString(lines[0].text) {
+		return []Error{{Line: lines[0].n, Message: fmt.Sprintf("invalid header %q", lines[0].text)}}
+	}
+
+	switch kind {
+	case Flowchart:
+		return checkFlowchart(lines[1:])
+	case Sequence:
+		return checkSequence(lines[1:])
+	default:
+		return checkClass(lines[1:])
+	}
+}
+
+// statements returns the non-blank lines of src, without %% comments,
+// %%{init}%% directives and YAML frontmatter.
+func statements(src string) []line {
+	var (
+		lines       []line
+		frontmatter bool
+	)
+	for i, text := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		text = strings.TrimSpace(text)
+		switch {
+		case i == 0 && text == "---":
+			frontmatter = true
+			continue
+		case frontmatter:
+			frontmatter = text != "---"
+			continue
+		case text == "", strings.HasPrefix(text, "%%"):
+			continue
+		}
+		lines = append(lines, line{n: i + 1, text: text})
+	}
+	return lines
+}
+
+// blocks tracks nested blocks closed by "end" or "}".
+type blocks struct {
+	open []line
+	errs []Error
+}
+
+func (b *blocks) push(l line) {
+	b.open = append(b.open, l)
+}
+
+// pop closes the innermost block, reporting a closer without a block.
+func (b *blocks) pop(l line) {
+	if len(b.open) == 0 {
+		b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("%q without an open block", l.text)})
+		return
+	}
+	b.open = b.open[:len(b.open)-1]
+}
+
+// innermost returns the first word of the innermost open block, or "".
+func (b *blocks) innermost() string {
+	if len(b.open) == 0 {
+		return ""
+	}
+	return strings.Fields(b.open[len(b.open)-1].text)[0]
+}
+
+// unclosed reports every block left open at the end of the diagram.
+func (b *blocks) unclosed(closer string) []Error {
+	errs := b.errs
+	for _, l := range b.open {
+		errs = append(errs, Error{Line: l.n, Message: fmt.Sprintf("%q is never closed with %q", l.text, closer)})
+	}
+	return errs
+}
@@ -0,0 +1,126 @@
+package mermaid
+
+import "testing"
+
+// Most of the valid diagrams are examples from the Mermaid documentation.
+func TestCheckFlowchart(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		valid bool
+	}{
+		{"node", "flowchart LR\n    id1[This is the text in the box]", true},
+		{"graph header", "graph TD;\n    A-->B;", true},
+		{"arrow", "flowchart LR\n    A-->B", true},
+		{"open link", "flowchart LR\n    A --- B", true},
+		{"text on open link", "flowchart LR\n    A-- This is the text! ---B", true},
+		{"label on open link", "flowchart LR\n    A---|This is the text|B", true},
+		{"label on arrow", "flowchart LR\n    A-->|text|B", true},
+		{"text on arrow", "flowchart LR\n    A-- text -->B", true},
+		{"dotted", "flowchart LR\n   A-.->B;", true},
+		{"dotted with text", "flowchart LR\n   A-. text .-> B", true},
+		{"thick", "flowchart LR\n   A ==> B", true},
+		{"thick with text", "flowchart LR\n   A == text ==> B", true},
+		{"invisible", "flowchart LR\n    A ~~~ B", true},
+		{"chain", "flowchart LR\n   A -- text --> B -- text2 --> C", true},
+		{"ampersands", "flowchart LR\n   a --> b & c--> d", true},
+		{"ampersands on both sides", "flowchart TB\n    A & B--> C & D", true},
+		{"circle and cross edges", "flowchart LR\n    A --o B\n    B --x C", true},
+		{"bidirectional", "flowchart LR\n    A o--o B\n    B <--> C\n    C x--x D", true},
+		{"longer arrow", "flowchart LR\n    A ---> B", true},
+		{"longer thick arrow", "flowchart LR\n    A ===> B", true},
+		{"longer arrow with label", "flowchart TD\n    A[Start] --> B{Is it?}\n    B -->|Yes| C[OK]\n    C --> D[Rethink]\n    D --> B\n    B ---->|No| E[End]", true},
+		{"shapes", "flowchart LR\n    a(round) --> b([stadium]) --> c[[subroutine]] --> d[(database)] --> e((circle))\n    f>asymmetric] --> g{rhombus} --> h{{hexagon}} --> i[/parallelogram/] --> j[\\alt\\] --> k[/trapezoid\\] --> l(((double)))", true},
+		{"quoted text", "flowchart LR\n    id1[\"This is the (text) in the box\"]", true},
+		{"subgraph", "flowchart TB\n    c1-->a2\n    subgraph one\n    a1-->a2\n    end", true},
+		{"subgraph direction", "flowchart LR\n  subgraph TOP\n    direction TB\n    top1 --> top2\n  end", true},
+		{"class", "flowchart LR\n    A:::someclass --> B\n    classDef someclass fill:#f96", true},
+		{"styling", "flowchart LR\n    id1(Start)-->id2(Stop)\n    style id1 fill:#f9f,stroke:#333,stroke-width:4px\n    linkStyle 0 stroke:#ff3", true},
+		{"comment", "flowchart LR\n%% this is a comment A -- text --> B{node}\n   A -- text --> B -- text2 --> C", true},
+
+		{"single dash", "flowchart LR\n    A -> B", false},
+		{"no target", "flowchart LR\n    A -->", false},
+		{"unclosed shape", "flowchart LR\n    A[Start --> B", false},
+		{"unquoted brackets", "flowchart LR\n    A[Run (fast)] --> B", false},
+		{"end as node", "flowchart LR\n    end --> B", false},
+		{"unclosed subgraph", "flowchart LR\n    subgraph one\n    A --> B", false},
+		{"bad direction", "flowchart LR\n  subgraph one\n    direction XY\n  end", false},
+		{"bad header", "flowchart XY\n    A --> B", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := Check(tt.src); (len(errs) == 0) != tt.valid {
+				t.Errorf("Check(%q) = %v, want valid %v", tt.src, errs, tt.valid)
+			}
+		})
+	}
+}
+
+func TestCheckSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		valid bool
+	}{
+		{"messages", "sequenceDiagram\n    Alice->>John: Hello John, how are you?\n    John-->>Alice: Great!\n    Alice-)John: See you later!", true},
+		{"participants", "sequenceDiagram\n    participant A as Alice\n    actor J as John\n    A->>J: Hello John", true},
+		{"activation", "sequenceDiagram\n    Alice->>+John: Hello John\n    John-->>-Alice: Hi Alice", true},
+		{"note", "sequenceDiagram\n    participant John\n    Note right of John: Text in note", true},
+		{"loop", "sequenceDiagram\n    Alice->John: Hello John\n    loop Every minute\n        John-->Alice: Great!\n    end", true},
+		{"alt", "sequenceDiagram\n    Alice->>Bob: Hello Bob\n    alt is sick\n        Bob->>Alice: Not so good\n    else is well\n        Bob->>Alice: Feeling fresh\n    end", true},
+
+		{"message without text", "sequenceDiagram\n    Alice->>John", false},
+		{"else outside alt", "sequenceDiagram\n    else oops", false},
+		{"unclosed loop", "sequenceDiagram\n    loop Every minute\n        John-->Alice: Great!", false},
+		{"bad note", "sequenceDiagram\n    Note John: text", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := Check(tt.src); (len(errs) == 0) != tt.valid {
+				t.Errorf("Check(%q) = %v, want valid %v", tt.src, errs, tt.valid)
+			}
+		})
+	}
+}
+
+func TestCheckClass(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		valid bool
+	}{
+		{"relationships", "classDiagram\n    Animal <|-- Duck\n    Animal <|-- Fish\n    Animal : +int age\n    Animal: +isMammal()", true},
+		{"class body", "classDiagram\n    class Duck{\n        +String beakColor\n        +swim()\n    }", true},
+		{"cardinality", "classDiagram\n    Customer \"1\" --> \"*\" Ticket\n    Student \"1\" --> \"1..*\" Course", true},
+		{"generics", "classDiagram\n    class Square~Shape~{\n        int id\n    }", true},
+		{"annotation", "classDiagram\n    class Shape\n    <<interface>> Shape", true},
+		{"label", "classDiagram\n    classA --|> classB : Inheritance\n    classC ..> classD : Dependency", true},
+
+		{"unclosed class body", "classDiagram\n    class Duck{\n        +swim()", false},
+		{"bad relationship", "classDiagram\n    Animal <|-- ", false},
+		{"unknown statement", "classDiagram\n    this is not valid", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := Check(tt.src); (len(errs) == 0) != tt.valid {
+				t.Errorf("Check(%q) = %v, want valid %v", tt.src, errs, tt.valid)
+			}
+		})
+	}
+}
+
+func TestType(t *testing.T) {
+	tests := map[string]string{
+		"flowchart LR\nA-->B":       Flowchart,
+		"graph TD\nA-->B":           Flowchart,
+		"sequenceDiagram\nA->>B: x": Sequence,
+		"classDiagram\nA <|-- B":    Class,
+		"pie\n\"a\" : 1":            "",
+		"":                          "",
+	}
+	for src, want := range tests {
+		if got := Type(src); got != want {
+			t.Errorf("Type(%q) = %q, want %q", src, got, want)
+		}
+	}
+}
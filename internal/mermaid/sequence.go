@@ -0,0 +1,70 @@
+package mermaid
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	participantPattern = regexp.MustCompile(`^(?:create\s+)?(?:participant|actor)\s+[^\s:]+(?:\s+as\s+.+)?$`)
+	destroyPattern     = regexp.MustCompile(`^destroy\s+\S+$`)
+	activationPattern  = regexp.MustCompile(`^(?:activate|deactivate)\s+\S+$`)
+	notePattern        = regexp.MustCompile(`(?i)^note\s+(?:left of|right of|over)\s+[^:,]+(?:,\s*[^:,]+)?\s*:.*$`)
+
+	// messageArrowPattern matches the arrow of a message, longest first.
+	messageArrowPattern = regexp.MustCompile(`<<-->>|<<->>|-->>|->>|-->|->|--x|-x|--\)|-\)`)
+
+	// messagePattern matches a complete message, e.g. "A->>+B: Hello".
+	messagePattern = regexp.MustCompile(`^[^:]+?\s*(?:<<-->>|<<->>|-->>|->>|-->|->|--x|-x|--\)|-\))\s*[+-]?\s*[^:\s][^:]*:.*$`)
+)
+
+// sequenceBlocks are the blocks closed by "end", with the statements that
+// may divide each of them.
+var sequenceBlocks = map[string]string{
+	"loop":     "",
+	"alt":      "else",
+	"opt":      "",
+	"par":      "and",
+	"critical": "option",
+	"break":    "",
+	"rect":     "",
+	"box":      "",
+}
+
+func checkSequence(lines []line) []Error {
+	var b blocks
+	for _, l := range lines {
+		first := strings.Fields(l.text)[0]
+		switch {
+		case l.text == "end":
+			b.pop(l)
+		case hasKey(sequenceBlocks, first):
+			b.push(l)
+		case first == "else" || first == "and" || first == "option":
+			if block := b.innermost(); sequenceBlocks[block] != first {
+				b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("%q outside the block it divides", first)})
+			}
+		case first == "autonumber", first == "title", strings.HasPrefix(first, "title:"):
+		case participantPattern.MatchString(l.text),
+			destroyPattern.MatchString(l.text),
+			activationPattern.MatchString(l.text):
+		case strings.EqualFold(first, "note"):
+			if !notePattern.MatchString(l.text) {
+				b.errs = append(b.errs, Error{Line: l.n, Message: `note must read "Note left of|right of|over <participant>: text"`})
+			}
+		case messageArrowPattern.MatchString(l.text):
+			if !messagePattern.MatchString(l.text) {
+				b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("message %q must read \"<from><arrow><to>: text\"", l.text)})
+			}
+		default:
+			b.errs = append(b.errs, Error{Line: l.n, Message: fmt.Sprintf("unrecognized statement %q", l.text)})
+		}
+	}
+	return b.unclosed("end")
+}
+
+func hasKey(m map[string]string, key string) bool {
+	_, ok := m[key]
+	return ok
+}
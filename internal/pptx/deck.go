@@ -0,0 +1,151 @@
+package pptx
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/deck"
+)
+
+var (
+	headingLine = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	listItem    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	imageLine   = regexp.MustCompile(`^\s*!\[[^\]]*\]\([^)]*\)\s*$`)
+	tableRule   = regexp.MustCompile(`^\s*\|?\s*:?-{3,}`)
+	fenceLine   = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// FromDeck converts a parsed Marp or Reveal.js deck into a presentation.
+// The first heading on each slide becomes its title; lists, text, further
+// headings and code blocks become body paragraphs, and speaker notes go to
+// the notes pane. Images are not exported.
+func FromDeck(d *deck.Deck) Presentation {
+	var p Presentation
+	if title, ok := d.Directives["title"].(string); ok {
+		p.Title = title
+	}
+
+	for _, s := range d.Slides {
+		slide := fromMarkdown(s.Body())
+		for _, note := range s.Notes {
+			for _, line := range strings.Split(note, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					slide.Notes = append(slide.Notes, plainText(line))
+				}
+			}
+		}
+		if p.Title == "" {
+			p.Title = slide.Title
+		}
+		p.Slides = append(p.Slides, slide)
+	}
+	return p
+}
+
+// fromMarkdown converts one slide's Markdown into a title and body.
+func fromMarkdown(md string) Slide {
+	var (
+		s    Slide
+		text []string
+		code bool
+	)
+	flush := func() {
+		if len(text) > 0 {
+			s.Body = append(s.Body, Paragraph{Kind: KindText, Runs: inlineRuns(strings.Join(text, " "))})
+			text = nil
+		}
+	}
+
+	for _, line := range strings.Split(md, "\n") {
+		if fenceLine.MatchString(line) {
+			flush()
+			code = !code
+			continue
+		}
+		if code {
+			s.Body = append(s.Body, Paragraph{Kind: KindCode, Runs: []Run{{Text: line, Code: true}}})
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case imageLine.MatchString(line), tableRule.MatchString(line):
+			// Not exported.
+		case headingLine.MatchString(line):
+			flush()
+			heading := headingLine.FindStringSubmatch(line)[2]
+			if s.Title == "" {
+				s.Title = plainText(heading)
+			} else {
+				s.Body = append(s.Body, Paragraph{Kind: KindHeading, Runs: inlineRuns(heading)})
+			}
+		case listItem.MatchString(line):
+			flush()
+			m := listItem.FindStringSubmatch(line)
+			kind := KindBullet
+			if m[2][0] >= '0' && m[2][0] <= '9' {
+				kind = KindNumber
+			}
+			indent := len(strings.ReplaceAll(m[1], "\t", "  "))
+			s.Body = append(s.Body, Paragraph{Kind: kind, Level: indent / 2, Runs: inlineRuns(m[3])})
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			cells := strings.Split(strings.Trim(trimmed, "|"), "|")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			s.Body = append(s.Body, Paragraph{Kind: KindText, Runs: inlineRuns(strings.Join(cells, "  |  "))})
+		default:
+			text = append(text, strings.TrimPrefix(trimmed, "> "))
+		}
+	}
+	flush()
+	return s
+}
+
+// inlinePattern matches the inline Markdown spans converted to runs.
+var inlinePattern = regexp.MustCompile("\\*\\*([^*]+)\\*\\*|__([^_]+)__|\\*([^*\\s][^*]*)\\*|\\b_([^_]+)_\\b|`([^`]+)`|\\[([^\\]]+)\\]\\([^)]*\\)")
+
+// inlineRuns splits Markdown text into formatted runs.
+func inlineRuns(text string) []Run {
+	var (
+		out  []Run
+		last int
+	)
+	for _, m := range inlinePattern.FindAllStringSubmatchIndex(text, -1) {
+		if m[0] > last {
+			out = append(out, Run{Text: text[last:m[0]]})
+		}
+		group := func(i int) string { return text[m[2*i]:m[2*i+1]] }
+		switch {
+		case m[2] >= 0:
+			out = append(out, Run{Text: group(1), Bold: true})
+		case m[4] >= 0:
+			out = append(out, Run{Text: group(2), Bold: true})
+		case m[6] >= 0:
+			out = append(out, Run{Text: group(3), Italic: true})
+		case m[8] >= 0:
+			out = append(out, Run{Text: group(4), Italic: true})
+		case m[10] >= 0:
+			out = append(out, Run{Text: group(5), Code: true})
+		case m[12] >= 0:
+			out = append(out, Run{Text: group(6)})
+		}
+		last = m[1]
+	}
+	if last < len(text) {
+		out = append(out, Run{Text: text[last:]})
+	}
+	return out
+}
+
+// plainText returns Markdown text with inline formatting removed.
+func plainText(text string) string {
+	var b strings.Builder
+	for _, r := range inlineRuns(text) {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
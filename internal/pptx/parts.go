@@ -0,0 +1,385 @@
+package pptx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// Namespace declarations shared by PresentationML parts.
+const pmlNamespaces = `xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+	`xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"`
+
+// Relationship types.
+const (
+	relOfficeDocument = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	relCoreProps      = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+	relExtendedProps  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
+	relSlideMaster    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideMaster"
+	relSlideLayout    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout"
+	relSlide          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide"
+	relNotesMaster    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesMaster"
+	relNotesSlide     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide"
+	relTheme          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"
+	relPresProps      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/presProps"
+	relViewProps      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/viewProps"
+	relTableStyles    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/tableStyles"
+)
+
+// Content types.
+const (
+	ctPresentation = "application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"
+	ctSlideMaster  = "application/vnd.openxmlformats-officedocument.presentationml.slideMaster+xml"
+	ctSlideLayout  = "application/vnd.openxmlformats-officedocument.presentationml.slideLayout+xml"
+	ctSlide        = "application/vnd.openxmlformats-officedocument.presentationml.slide+xml"
+	ctNotesMaster  = "application/vnd.openxmlformats-officedocument.presentationml.notesMaster+xml"
+	ctNotesSlide   = "application/vnd.openxmlformats-officedocument.presentationml.notesSlide+xml"
+	ctTheme        = "application/vnd.openxmlformats-officedocument.theme+xml"
+	ctPresProps    = "application/vnd.openxmlformats-officedocument.presentationml.presProps+xml"
+	ctViewProps    = "application/vnd.openxmlformats-officedocument.presentationml.viewProps+xml"
+	ctTableStyles  = "application/vnd.openxmlformats-officedocument.presentationml.tableStyles+xml"
+	ctCoreProps    = "application/vnd.openxmlformats-package.core-properties+xml"
+	ctExtended     = "application/vnd.openxmlformats-officedocument.extended-properties+xml"
+)
+
+// Slide geometry in EMUs for a 16:9 deck.
+const (
+	slideWidth  = 12192000
+	slideHeight = 6858000
+)
+
+// rel is one relationship in a .rels part.
+type rel struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+func parseRels(data string) []rel {
+	var rels struct {
+		Relationships []rel `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal([]byte(data), &rels); err != nil {
+		return nil
+	}
+	return rels.Relationships
+}
+
+func relsXML(rels ...rel) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for _, r := range rels {
+		fmt.Fprintf(&b, `<Relationship Id="%s" Type="%s" Target="%s"/>`, r.ID, r.Type, r.Target)
+	}
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func contentTypes(p Presentation) string {
+	overrides := [][2]string{
+		{"/ppt/presentation.xml", ctPresentation},
+		{"/ppt/slideMasters/slideMaster1.xml", ctSlideMaster},
+		{"/ppt/slideLayouts/slideLayout1.xml", ctSlideLayout},
+		{"/ppt/notesMasters/notesMaster1.xml", ctNotesMaster},
+		{"/ppt/theme/theme1.xml", ctTheme},
+		{"/ppt/theme/theme2.xml", ctTheme},
+		{"/ppt/presProps.xml", ctPresProps},
+		{"/ppt/viewProps.xml", ctViewProps},
+		{"/ppt/tableStyles.xml", ctTableStyles},
+		{"/docProps/core.xml", ctCoreProps},
+		{"/docProps/app.xml", ctExtended},
+	}
+	for i := range p.Slides {
+		overrides = append(overrides,
+			[2]string{fmt.Sprintf("/ppt/slides/slide%d.xml", i+1), ctSlide},
+			[2]string{fmt.Sprintf("/ppt/notesSlides/notesSlide%d.xml", i+1), ctNotesSlide},
+		)
+	}
+
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	for _, o := range overrides {
+		fmt.Fprintf(&b, `<Override PartName="%s" ContentType="%s"/>`, o[0], o[1])
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+var rootRels = relsXML(
+	rel{"rId1", relOfficeDocument, "ppt/presentation.xml"},
+	rel{"rId2", relCoreProps, "docProps/core.xml"},
+	rel{"rId3", relExtendedProps, "docProps/app.xml"},
+)
+
+func coreProps(p Presentation) string {
+	return xmlHeader + `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+		`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" ` +
+		`xmlns:dcmitype="http://purl.org/dc/dcmitype/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<dc:title>` + esc(p.Title) + `</dc:title><dc:creator>content</dc:creator></cp:coreProperties>`
+}
+
+func appProps(p Presentation) string {
+	return xmlHeader + `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties" ` +
+		`xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes">` +
+		fmt.Sprintf(`<Application>content</Application><Slides>%d</Slides><Notes>%d</Notes>`, len(p.Slides), len(p.Slides)) +
+		`</Properties>`
+}
+
+// firstSlideRel is the relationship ID of the first slide in
+// presentation.xml.rels; rId1-rId6 are used by the other parts.
+const firstSlideRel = 7
+
+func presentation(p Presentation) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<p:presentation ` + pmlNamespaces + ` saveSubsetFonts="1">`)
+	b.WriteString(`<p:sldMasterIdLst><p:sldMasterId id="2147483648" r:id="rId1"/></p:sldMasterIdLst>`)
+	b.WriteString(`<p:notesMasterIdLst><p:notesMasterId r:id="rId2"/></p:notesMasterIdLst>`)
+	if len(p.Slides) > 0 {
+		b.WriteString(`<p:sldIdLst>`)
+		for i := range p.Slides {
+			fmt.Fprintf(&b, `<p:sldId id="%d" r:id="rId%d"/>`, 256+i, firstSlideRel+i)
+		}
+		b.WriteString(`</p:sldIdLst>`)
+	}
+	fmt.Fprintf(&b, `<p:sldSz cx="%d" cy="%d"/>`, slideWidth, slideHeight)
+	b.WriteString(`<p:notesSz cx="6858000" cy="9144000"/>`)
+	b.WriteString(`</p:presentation>`)
+	return b.String()
+}
+
+func presentationRels(p Presentation) string {
+	rels := []rel{
+		{"rId1", relSlideMaster, "slideMasters/slideMaster1.xml"},
+		{"rId2", relNotesMaster, "notesMasters/notesMaster1.xml"},
+		{"rId3", relTheme, "theme/theme1.xml"},
+		{"rId4", relPresProps, "presProps.xml"},
+		{"rId5", relViewProps, "viewProps.xml"},
+		{"rId6", relTableStyles, "tableStyles.xml"},
+	}
+	for i := range p.Slides {
+		rels = append(rels, rel{fmt.Sprintf("rId%d", firstSlideRel+i), relSlide, fmt.Sprintf("slides/slide%d.xml", i+1)})
+	}
+	return relsXML(rels...)
+}
+
+const presProps = xmlHeader + `<p:presentationPr ` + pmlNamespaces + `/>`
+
+const viewProps = xmlHeader + `<p:viewPr ` + pmlNamespaces + `>` +
+	`<p:normalViewPr><p:restoredLeft sz="15620"/><p:restoredTop sz="80000"/></p:normalViewPr>` +
+	`<p:gridSpacing cx="76200" cy="76200"/></p:viewPr>`
+
+const tableStyles = xmlHeader + `<a:tblStyleLst xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" ` +
+	`def="{5C22544A-7EE6-4342-B048-85BDC9FD1C3A}"/>`
+
+// Group shape properties every shape tree starts with.
+const spTreeStart = `<p:spTree><p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>` +
+	`<p:grpSpPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/><a:chOff x="0" y="0"/><a:chExt cx="0" cy="0"/></a:xfrm></p:grpSpPr>`
+
+const colorMap = `<p:clrMap bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" ` +
+	`accent3="accent3" accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"/>`
+
+// levelStyle returns a list level paragraph style for the master's body
+// text: lvl is 1-based, size is in hundredths of a point.
+func levelStyle(lvl, size int) string {
+	marL := 228600 + (lvl-1)*457200
+	return fmt.Sprintf(`<a:lvl%dpPr marL="%d" indent="-228600" algn="l"><a:lnSpc><a:spcPct val="90000"/></a:lnSpc>`+
+		`<a:spcBef><a:spcPts val="1000"/></a:spcBef><a:buFont typeface="Arial"/><a:buChar char="&#8226;"/>`+
+		`<a:defRPr sz="%d" kern="1200"><a:solidFill><a:schemeClr val="tx1"/></a:solidFill>`+
+		`<a:latin typeface="+mn-lt"/><a:ea typeface="+mn-ea"/><a:cs typeface="+mn-cs"/></a:defRPr></a:lvl%dpPr>`, lvl, marL, size, lvl)
+}
+
+var slideMaster = xmlHeader + `<p:sldMaster ` + pmlNamespaces + `>` +
+	`<p:cSld><p:bg><p:bgRef idx="1001"><a:schemeClr val="bg1"/></p:bgRef></p:bg>` + spTreeStart +
+	placeholder(2, "Title Placeholder 1", `type="title"`, 838200, 365125, 10515600, 1325563, `anchor="ctr"`, "Click to edit Master title style") +
+	placeholder(3, "Text Placeholder 2", `type="body" idx="1"`, 838200, 1825625, 10515600, 4351338, "", "Click to edit Master text styles") +
+	`</p:spTree></p:cSld>` + colorMap +
+	`<p:sldLayoutIdLst><p:sldLayoutId id="2147483649" r:id="rId1"/></p:sldLayoutIdLst>` +
+	`<p:txStyles><p:titleStyle><a:lvl1pPr algn="l"><a:lnSpc><a:spcPct val="90000"/></a:lnSpc><a:spcBef><a:spcPct val="0"/></a:spcBef><a:buNone/>` +
+	`<a:defRPr sz="4000" kern="1200"><a:solidFill><a:schemeClr val="tx1"/></a:solidFill>` +
+	`<a:latin typeface="+mj-lt"/><a:ea typeface="+mj-ea"/><a:cs typeface="+mj-cs"/></a:defRPr></a:lvl1pPr></p:titleStyle>` +
+	`<p:bodyStyle>` + levelStyle(1, 2600) + levelStyle(2, 2200) + levelStyle(3, 2000) + levelStyle(4, 1800) + levelStyle(5, 1800) + `</p:bodyStyle>` +
+	`<p:otherStyle><a:defPPr><a:defRPr lang="en-US"/></a:defPPr></p:otherStyle></p:txStyles></p:sldMaster>`
+
+var slideMasterRels = relsXML(
+	rel{"rId1", relSlideLayout, "../slideLayouts/slideLayout1.xml"},
+	rel{"rId2", relTheme, "../theme/theme1.xml"},
+)
+
+// placeholder returns a placeholder shape with a position, as used on
+// masters.
+func placeholder(id int, name, ph string, x, y, cx, cy int, bodyPr, prompt string) string {
+	return fmt.Sprintf(`<p:sp><p:nvSpPr><p:cNvPr id="%d" name="%s"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>`+
+		`<p:nvPr><p:ph %s/></p:nvPr></p:nvSpPr>`+
+		`<p:spPr><a:xfrm><a:off x="%d" y="%d"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></p:spPr>`+
+		`<p:txBody><a:bodyPr %s><a:normAutofit/></a:bodyPr><a:lstStyle/><a:p><a:r><a:rPr lang="en-US"/><a:t>%s</a:t></a:r></a:p></p:txBody></p:sp>`,
+		id, name, ph, x, y, cx, cy, bodyPr, prompt)
+}
+
+var slideLayout = xmlHeader + `<p:sldLayout ` + pmlNamespaces + ` type="obj" preserve="1">` +
+	`<p:cSld name="Title and Content">` + spTreeStart +
+	`<p:sp><p:nvSpPr><p:cNvPr id="2" name="Title 1"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr>` +
+	`<p:spPr/><p:txBody><a:bodyPr/><a:lstStyle/><a:p><a:r><a:rPr lang="en-US"/><a:t>Click to edit Master title style</a:t></a:r></a:p></p:txBody></p:sp>` +
+	`<p:sp><p:nvSpPr><p:cNvPr id="3" name="Content Placeholder 2"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr>` +
+	`<p:spPr/><p:txBody><a:bodyPr/><a:lstStyle/><a:p><a:r><a:rPr lang="en-US"/><a:t>Click to edit Master text styles</a:t></a:r></a:p></p:txBody></p:sp>` +
+	`</p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sldLayout>`
+
+var slideLayoutRels = relsXML(rel{"rId1", relSlideMaster, "../slideMasters/slideMaster1.xml"})
+
+var notesMaster = xmlHeader + `<p:notesMaster ` + pmlNamespaces + `>` +
+	`<p:cSld><p:bg><p:bgRef idx="1001"><a:schemeClr val="bg1"/></p:bgRef></p:bg>` + spTreeStart +
+	`<p:sp><p:nvSpPr><p:cNvPr id="2" name="Slide Image Placeholder 1"/><p:cNvSpPr><a:spLocks noGrp="1" noRot="1" noChangeAspect="1"/></p:cNvSpPr>` +
+	`<p:nvPr><p:ph type="sldImg" idx="2"/></p:nvPr></p:nvSpPr>` +
+	`<p:spPr><a:xfrm><a:off x="685800" y="1143000"/><a:ext cx="5486400" cy="3086100"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom>` +
+	`<a:noFill/><a:ln w="12700"><a:solidFill><a:prstClr val="black"/></a:solidFill></a:ln></p:spPr></p:sp>` +
+	`<p:sp><p:nvSpPr><p:cNvPr id="3" name="Notes Placeholder 2"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>` +
+	`<p:nvPr><p:ph type="body" sz="quarter" idx="3"/></p:nvPr></p:nvSpPr>` +
+	`<p:spPr><a:xfrm><a:off x="685800" y="4400550"/><a:ext cx="5486400" cy="3600450"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></p:spPr>` +
+	`<p:txBody><a:bodyPr/><a:lstStyle/><a:p><a:r><a:rPr lang="en-US"/><a:t>Click to edit Master text styles</a:t></a:r></a:p></p:txBody></p:sp>` +
+	`</p:spTree></p:cSld>` + colorMap +
+	`<p:notesStyle><a:lvl1pPr marL="0" algn="l"><a:defRPr sz="1200" kern="1200"><a:solidFill><a:schemeClr val="tx1"/></a:solidFill>` +
+	`<a:latin typeface="+mn-lt"/><a:ea typeface="+mn-ea"/><a:cs typeface="+mn-cs"/></a:defRPr></a:lvl1pPr></p:notesStyle></p:notesMaster>`
+
+var notesMasterRels = relsXML(rel{"rId1", relTheme, "../theme/theme2.xml"})
+
+func slideRels(n int) string {
+	return relsXML(
+		rel{"rId1", relSlideLayout, "../slideLayouts/slideLayout1.xml"},
+		rel{"rId2", relNotesSlide, fmt.Sprintf("../notesSlides/notesSlide%d.xml", n)},
+	)
+}
+
+func notesRels(n int) string {
+	return relsXML(
+		rel{"rId1", relNotesMaster, "../notesMasters/notesMaster1.xml"},
+		rel{"rId2", relSlide, fmt.Sprintf("../slides/slide%d.xml", n)},
+	)
+}
+
+// slideXML renders a slide's title and body placeholders.
+func slideXML(s Slide) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<p:sld ` + pmlNamespaces + `><p:cSld>` + spTreeStart)
+	if s.Title != "" {
+		b.WriteString(`<p:sp><p:nvSpPr><p:cNvPr id="2" name="Title 1"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>` +
+			`<p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr><p:spPr/>` +
+			`<p:txBody><a:bodyPr><a:normAutofit/></a:bodyPr><a:lstStyle/><a:p>`)
+		writeRuns(&b, runs(s.Title), "")
+		b.WriteString(`</a:p></p:txBody></p:sp>`)
+	}
+	if len(s.Body) > 0 {
+		b.WriteString(`<p:sp><p:nvSpPr><p:cNvPr id="3" name="Content Placeholder 2"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>` +
+			`<p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr><p:spPr/>` +
+			`<p:txBody><a:bodyPr><a:normAutofit/></a:bodyPr><a:lstStyle/>`)
+		for _, p := range s.Body {
+			writeParagraph(&b, p)
+		}
+		b.WriteString(`</p:txBody></p:sp>`)
+	}
+	b.WriteString(`</p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sld>`)
+	return b.String()
+}
+
+// noBullet is the paragraph property for unbulleted body text.
+const noBullet = `<a:pPr marL="0" indent="0"><a:buNone/></a:pPr>`
+
+func writeParagraph(b *strings.Builder, p Paragraph) {
+	b.WriteString(`<a:p>`)
+	level := min(p.Level, 4)
+	switch p.Kind {
+	case KindBullet:
+		if level > 0 {
+			fmt.Fprintf(b, `<a:pPr lvl="%d"/>`, level)
+		}
+	case KindNumber:
+		fmt.Fprintf(b, `<a:pPr marL="%d" lvl="%d" indent="-457200"><a:buFont typeface="+mj-lt"/><a:buAutoNumber type="arabicPeriod"/></a:pPr>`,
+			457200*(level+1), level)
+	case KindCode:
+		b.WriteString(`<a:pPr marL="0" indent="0"><a:spcBef><a:spcPts val="0"/></a:spcBef><a:buNone/></a:pPr>`)
+	default:
+		b.WriteString(noBullet)
+	}
+
+	rs := p.Runs
+	if p.Kind == KindHeading {
+		rs = make([]Run, len(p.Runs))
+		for i, r := range p.Runs {
+			r.Bold = true
+			rs[i] = r
+		}
+	}
+	size := ""
+	if p.Kind == KindCode {
+		size = "1400"
+	}
+	writeRuns(b, rs, size)
+	if len(rs) == 0 {
+		b.WriteString(`<a:endParaRPr lang="en-US" dirty="0"/>`)
+	}
+	b.WriteString(`</a:p>`)
+}
+
+// writeRuns writes text runs. size, if set, is the font size in hundredths
+// of a point.
+func writeRuns(b *strings.Builder, rs []Run, size string) {
+	for _, r := range rs {
+		b.WriteString(`<a:r><a:rPr lang="en-US"`)
+		if size != "" {
+			b.WriteString(` sz="` + size + `"`)
+		}
+		if r.Bold {
+			b.WriteString(` b="1"`)
+		}
+		if r.Italic {
+			b.WriteString(` i="1"`)
+		}
+		b.WriteString(` dirty="0"`)
+		if r.Code {
+			b.WriteString(`><a:latin typeface="Consolas"/><a:cs typeface="Consolas"/></a:rPr>`)
+		} else {
+			b.WriteString(`/>`)
+		}
+		b.WriteString(`<a:t>` + esc(r.Text) + `</a:t></a:r>`)
+	}
+}
+
+// notesXML renders a slide's notes page.
+func notesXML(s Slide) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<p:notes ` + pmlNamespaces + `><p:cSld>` + spTreeStart)
+	b.WriteString(`<p:sp><p:nvSpPr><p:cNvPr id="2" name="Slide Image Placeholder 1"/>` +
+		`<p:cNvSpPr><a:spLocks noGrp="1" noRot="1" noChangeAspect="1"/></p:cNvSpPr><p:nvPr><p:ph type="sldImg"/></p:nvPr></p:nvSpPr><p:spPr/></p:sp>`)
+	b.WriteString(`<p:sp><p:nvSpPr><p:cNvPr id="3" name="Notes Placeholder 2"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>` +
+		`<p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr><p:spPr/><p:txBody><a:bodyPr/><a:lstStyle/>`)
+	if len(s.Notes) == 0 {
+		b.WriteString(`<a:p><a:endParaRPr lang="en-US" dirty="0"/></a:p>`)
+	}
+	for _, note := range s.Notes {
+		b.WriteString(`<a:p>`)
+		writeRuns(&b, []Run{{Text: note}}, "")
+		b.WriteString(`</a:p>`)
+	}
+	b.WriteString(`</p:txBody></p:sp></p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:notes>`)
+	return b.String()
+}
+
+// runs returns text as a single plain run.
+func runs(text string) []Run {
+	return []Run{{Text: text}}
+}
+
+// esc escapes text for XML character data and attribute values.
+func esc(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
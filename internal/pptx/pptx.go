@@ -0,0 +1,185 @@
+// Package pptx writes slide decks as PowerPoint (Office Open XML) files.
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Presentation is a deck to export.
+type Presentation struct {
+	Title  string
+	Slides []Slide
+}
+
+// Slide is a single slide: a title, body paragraphs and speaker notes.
+type Slide struct {
+	Title string
+	Body  []Paragraph
+	Notes []string // Notes pane paragraphs
+}
+
+// Paragraph kinds.
+const (
+	KindText    = "text"
+	KindBullet  = "bullet"
+	KindNumber  = "number" // Numbered list item
+	KindHeading = "heading"
+	KindCode    = "code" // One line of a code block
+)
+
+// Paragraph is one paragraph of slide body text.
+type Paragraph struct {
+	Kind  string
+	Level int // List nesting level, 0-based
+	Runs  []Run
+}
+
+// Run is a span of text with uniform formatting.
+type Run struct {
+	Text   string
+	Bold   bool
+	Italic bool
+	Code   bool
+}
+
+// Options configures the exported file.
+type Options struct {
+	// Template is a .pptx file whose theme supplies colors and fonts.
+	Template []byte
+}
+
+// Write writes p as a .pptx file to w.
+func Write(w io.Writer, p Presentation, opts Options) error {
+	theme := defaultTheme
+	if len(opts.Template) > 0 {
+		var err error
+		if theme, err = templateTheme(opts.Template); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []part{
+		{"[Content_Types].xml", contentTypes(p)},
+		{"_rels/.rels", rootRels},
+		{"docProps/core.xml", coreProps(p)},
+		{"docProps/app.xml", appProps(p)},
+		{"ppt/presentation.xml", presentation(p)},
+		{"ppt/_rels/presentation.xml.rels", presentationRels(p)},
+		{"ppt/presProps.xml", presProps},
+		{"ppt/viewProps.xml", viewProps},
+		{"ppt/tableStyles.xml", tableStyles},
+		{"ppt/theme/theme1.xml", theme},
+		{"ppt/theme/theme2.xml", theme},
+		{"ppt/slideMasters/slideMaster1.xml", slideMaster},
+		{"ppt/slideMasters/_rels/slideMaster1.xml.rels", slideMasterRels},
+		{"ppt/slideLayouts/slideLayout1.xml", slideLayout},
+		{"ppt/slideLayouts/_rels/slideLayout1.xml.rels", slideLayoutRels},
+		{"ppt/notesMasters/notesMaster1.xml", notesMaster},
+		{"ppt/notesMasters/_rels/notesMaster1.xml.rels", notesMasterRels},
+	}
+	for i, s := range p.Slides {
+		n := i + 1
+		parts = append(parts,
+			part{fmt.Sprintf("ppt/slides/slide%d.xml", n), slideXML(s)},
+			part{fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", n), slideRels(n)},
+			part{fmt.Sprintf("ppt/notesSlides/notesSlide%d.xml", n), notesXML(s)},
+			part{fmt.Sprintf("ppt/notesSlides/_rels/notesSlide%d.xml.rels", n), notesRels(n)},
+		)
+	}
+
+	for _, pt := range parts {
+		f, err := zw.Create(pt.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, pt.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// part is a file in the package.
+type part struct {
+	name    string
+	content string
+}
+
+// templateTheme returns the theme used by the first slide master of a
+// .pptx template.
+func templateTheme(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	read := func(name string) (string, error) {
+		f, err := zr.Open(name)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		b, err := io.ReadAll(f)
+		return string(b), err
+	}
+
+	master, err := relTarget(read, "ppt/_rels/presentation.xml.rels", "ppt", "/slideMaster")
+	if err != nil {
+		return "", err
+	}
+	dir, file := splitPath(master)
+	theme, err := relTarget(read, dir+"/_rels/"+file+".rels", dir, "/theme")
+	if err != nil {
+		return "", err
+	}
+	return read(theme)
+}
+
+// relTarget finds the first relationship in a .rels part whose type ends
+// with typeSuffix and returns its target resolved against base.
+func relTarget(read func(string) (string, error), relsPart, base, typeSuffix string) (string, error) {
+	rels, err := read(relsPart)
+	if err != nil {
+		return "", err
+	}
+	for _, rel := range parseRels(rels) {
+		if strings.HasSuffix(rel.Type, typeSuffix) {
+			return resolve(base, rel.Target), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no %s relationship", relsPart, strings.TrimPrefix(typeSuffix, "/"))
+}
+
+// resolve joins a relationship target onto the directory of its source
+// part, handling ../ segments and absolute targets.
+func resolve(base, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	segments := strings.Split(base, "/")
+	for _, seg := range strings.Split(target, "/") {
+		switch seg {
+		case "..":
+			if len(segments) > 0 {
+				segments = segments[:len(segments)-1]
+			}
+		case ".", "":
+		default:
+			segments = append(segments, seg)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func splitPath(name string) (string, string) {
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return "", name
+	}
+	return name[:i], name[i+1:]
+}
@@ -0,0 +1,309 @@
+(function () {
+  var deck = document.querySelector('.deck');
+  var width = Number(deck.dataset.width);
+  var height = Number(deck.dataset.height);
+  var slides = Array.prototype.slice.call(deck.querySelectorAll('section[data-slide]'));
+  var stacks = Array.prototype.slice.call(deck.querySelectorAll('section.stack'));
+  var panel = document.querySelector('.notes-panel');
+  var progress = document.querySelector('.progress');
+  var number = document.querySelector('.slide-number');
+  var plugins = (deck.dataset.plugins || '').split(' ');
+  var current = 0;
+  var search = null;
+  var mermaid = null;
+
+  // mermaidURL is the mermaid.js build used to draw ```mermaid blocks. It
+  // is not bundled, so diagrams are drawn only when the page is online and
+  // stay code blocks otherwise.
+  var mermaidURL = 'https://cdn.jsdelivr.net/npm/mermaid@11/dist/mermaid.esm.min.mjs';
+
+  function enabled(plugin) {
+    return plugins.indexOf(plugin) >= 0;
+  }
+
+  function fit() {
+    deck.style.setProperty('--scale', Math.min(window.innerWidth / width, window.innerHeight / height));
+  }
+
+  function show(n) {
+    if (slides.length === 0) {
+      return;
+    }
+    current = Math.max(0, Math.min(slides.length - 1, n));
+    slides.forEach(function (s, i) {
+      s.hidden = i !== current;
+      s.classList.toggle('present', i === current);
+    });
+    stacks.forEach(function (s) {
+      s.hidden = !s.contains(slides[current]);
+    });
+    slides[current].querySelectorAll('pre[data-line-numbers]').forEach(function (pre) {
+      highlightLines(pre, 0);
+    });
+    zoom(null);
+    drawDiagrams();
+
+    var notes = slides[current].querySelector(':scope > aside.notes');
+    panel.innerHTML = notes ? notes.innerHTML : '<em>No speaker notes</em>';
+    progress.style.width = ((current + 1) / slides.length) * 100 + '%';
+    if (deck.hasAttribute('data-slide-number')) {
+      number.hidden = false;
+      number.textContent = slideNumber();
+    }
+    history.replaceState(null, '', '#' + (current + 1));
+  }
+
+  // lineSteps returns the steps of a code block's line highlights, e.g.
+  // [[1], [2, 3]] for "1|2-3". An empty step highlights every line.
+  function lineSteps(pre) {
+    return pre.dataset.lineNumbers.split('|').map(function (step) {
+      var lines = [];
+      step.split(',').forEach(function (range) {
+        var bounds = range.split('-').map(Number);
+        if (range === '' || bounds.some(isNaN)) {
+          return;
+        }
+        for (var n = bounds[0]; n <= bounds[bounds.length - 1]; n++) {
+          lines.push(n);
+        }
+      });
+      return lines;
+    });
+  }
+
+  function highlightLines(pre, step) {
+    var lines = lineSteps(pre)[step];
+    pre.dataset.step = step;
+    pre.classList.toggle('has-highlights', lines.length > 0);
+    pre.querySelectorAll('.line').forEach(function (line, i) {
+      line.classList.toggle('highlight-line', lines.indexOf(i + 1) >= 0);
+    });
+  }
+
+  // step moves the current slide's line highlights by delta, returning
+  // false when there is no step in that direction, as reveal.js treats them
+  // as fragments.
+  function step(delta) {
+    var blocks = slides[current].querySelectorAll('pre[data-line-numbers]');
+    for (var i = 0; i < blocks.length; i++) {
+      var pre = blocks[delta > 0 ? i : blocks.length - 1 - i];
+      var next = Number(pre.dataset.step) + delta;
+      if (next >= 0 && next < lineSteps(pre).length) {
+        highlightLines(pre, next);
+        return true;
+      }
+    }
+    return false;
+  }
+
+  function forward(n) {
+    if (!step(1)) {
+      show(n);
+    }
+  }
+
+  function back(n) {
+    if (!step(-1)) {
+      show(n);
+    }
+  }
+
+  // zoom magnifies the current slide around the point (x, y) in client
+  // coordinates, or resets it when point is null.
+  function zoom(point) {
+    var slide = slides[current];
+    if (!point || slide.classList.contains('zoomed')) {
+      slides.forEach(function (s) {
+        s.classList.remove('zoomed');
+      });
+      return;
+    }
+    var rect = slide.getBoundingClientRect();
+    slide.style.transformOrigin = ((point.x - rect.left) / rect.width) * 100 + '% ' +
+      ((point.y - rect.top) / rect.height) * 100 + '%';
+    slide.classList.add('zoomed');
+  }
+
+  // find shows the next slide after the current one containing text.
+  function find(text) {
+    text = text.trim().toLowerCase();
+    if (text === '') {
+      return;
+    }
+    for (var i = 1; i <= slides.length; i++) {
+      var n = (current + i) % slides.length;
+      if (slides[n].textContent.toLowerCase().indexOf(text) >= 0) {
+        show(n);
+        return;
+      }
+    }
+    search.classList.add('not-found');
+  }
+
+  function openSearch() {
+    if (!search) {
+      search = document.createElement('input');
+      search.type = 'search';
+      search.className = 'search';
+      search.placeholder = 'Search';
+      search.addEventListener('keydown', function (e) {
+        e.stopPropagation();
+        search.classList.remove('not-found');
+        if (e.key === 'Enter') {
+          find(search.value);
+        } else if (e.key === 'Escape') {
+          search.hidden = true;
+          search.blur();
+        }
+      });
+      document.body.appendChild(search);
+    }
+    search.hidden = false;
+    search.focus();
+    search.select();
+  }
+
+  function loadMermaid() {
+    var blocks = deck.querySelectorAll('code.language-mermaid');
+    if (blocks.length === 0) {
+      return;
+    }
+    import(mermaidURL).then(function (module) {
+      blocks.forEach(function (code) {
+        var pre = code.parentNode;
+        pre.className = 'mermaid';
+        pre.removeAttribute('data-line-numbers');
+        pre.textContent = code.textContent;
+      });
+      mermaid = module.default;
+      mermaid.initialize({ startOnLoad: false });
+      drawDiagrams();
+    }).catch(function () {});
+  }
+
+  // drawDiagrams draws the current slide's diagrams. Hidden slides have no
+  // size, so each diagram is drawn when its slide is first shown.
+  function drawDiagrams() {
+    if (!mermaid) {
+      return;
+    }
+    var nodes = slides[current].querySelectorAll('pre.mermaid:not([data-processed])');
+    if (nodes.length > 0) {
+      mermaid.run({ nodes: nodes });
+    }
+  }
+
+  // slideNumber formats the current position as reveal.js does: the column
+  // number, followed by the row for vertical slides.
+  function slideNumber() {
+    var h = slides[current].dataset.h;
+    var v = 0;
+    for (var i = current - 1; i >= 0 && slides[i].dataset.h === h; i--) {
+      v++;
+    }
+    return v > 0 ? (Number(h) + 1) + '.' + v : String(Number(h) + 1);
+  }
+
+  // column returns the index of the first slide in the horizontal position
+  // offset columns away from the current slide.
+  function column(offset) {
+    var target = Number(slides[current].dataset.h) + offset;
+    for (var i = 0; i < slides.length; i++) {
+      if (Number(slides[i].dataset.h) === target) {
+        return i;
+      }
+    }
+    return current;
+  }
+
+  // row returns the index of the adjacent slide in the same column.
+  function row(offset) {
+    var next = slides[current + offset];
+    if (next && next.dataset.h === slides[current].dataset.h) {
+      return current + offset;
+    }
+    return current;
+  }
+
+  document.addEventListener('keydown', function (e) {
+    // Ctrl+Shift+F opens the search box, as in reveal.js's search plugin.
+    if (enabled('search') && e.ctrlKey && e.shiftKey && e.key.toLowerCase() === 'f') {
+      openSearch();
+      e.preventDefault();
+      return;
+    }
+    if (e.ctrlKey || e.metaKey || e.altKey) {
+      return;
+    }
+    switch (e.key) {
+      case 'ArrowRight':
+        forward(column(1));
+        break;
+      case 'ArrowLeft':
+        back(column(-1));
+        break;
+      case 'ArrowDown':
+        forward(row(1));
+        break;
+      case 'ArrowUp':
+        back(row(-1));
+        break;
+      case ' ':
+      case 'PageDown':
+      case 'Enter':
+        forward(current + 1);
+        break;
+      case 'Backspace':
+      case 'PageUp':
+        back(current - 1);
+        break;
+      case 'Home':
+        show(0);
+        break;
+      case 'End':
+        show(slides.length - 1);
+        break;
+      case 'n':
+      case 's':
+        if (!enabled('notes')) {
+          return;
+        }
+        panel.hidden = !panel.hidden;
+        break;
+      case 'Escape':
+        zoom(null);
+        break;
+      case 'f':
+        if (document.fullscreenElement) {
+          document.exitFullscreen();
+        } else {
+          document.documentElement.requestFullscreen();
+        }
+        break;
+      default:
+        return;
+    }
+    e.preventDefault();
+  });
+
+  deck.addEventListener('click', function (e) {
+    if (e.target.closest('a')) {
+      return;
+    }
+    // Alt+click zooms, as in reveal.js's zoom plugin (Ctrl+click on Linux).
+    if (enabled('zoom') && (e.altKey || e.ctrlKey)) {
+      zoom({ x: e.clientX, y: e.clientY });
+      return;
+    }
+    forward(current + 1);
+  });
+
+  window.addEventListener('resize', fit);
+  window.addEventListener('hashchange', function () {
+    show(parseInt(location.hash.slice(1), 10) - 1 || 0);
+  });
+
+  fit();
+  show(parseInt(location.hash.slice(1), 10) - 1 || 0);
+  loadMermaid();
+})();
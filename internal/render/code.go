@@ -0,0 +1,109 @@
+package render
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// codeStyle is the chroma style for highlighted code, matching the monokai
+// theme reveal.js's highlight plugin uses by default.
+const codeStyle = "monokai"
+
+// lineStepsPattern matches reveal.js line highlights after a code block's
+// language, e.g. [1|2-3] or [1,4-5].
+var lineStepsPattern = regexp.MustCompile(`\[([\d,|\s-]*)\]\s*$`)
+
+// highlightExtension renders fenced code blocks with chroma, one
+// <span class="line"> per line, and keeps any reveal.js line highlights in a
+// data-line-numbers attribute for the player to step through.
+type highlightExtension struct{}
+
+func (highlightExtension) Extend(m goldmark.Markdown) {
+	// Registered ahead of goldmark's own fenced code block renderer.
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(codeRenderer{}, 100)))
+}
+
+type codeRenderer struct{}
+
+func (codeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, renderCode)
+}
+
+func renderCode(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.FencedCodeBlock)
+
+	var info string
+	if n.Info != nil {
+		info = string(n.Info.Segment.Value(source))
+	}
+	var steps string
+	hasSteps := false
+	if m := lineStepsPattern.FindStringSubmatchIndex(info); m != nil {
+		steps = strings.Join(strings.Fields(info[m[2]:m[3]]), "")
+		hasSteps = true
+		info = info[:m[0]]
+	}
+	language, _, _ := strings.Cut(strings.TrimSpace(info), " ")
+
+	var code bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		code.Write(line.Value(source))
+	}
+
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code.String())
+	if err != nil {
+		return ast.WalkStop, err
+	}
+
+	w.WriteString(`<pre class="chroma"`)
+	if hasSteps {
+		w.WriteString(` data-line-numbers="` + html.EscapeString(steps) + `"`)
+	}
+	w.WriteString("><code")
+	if language != "" {
+		w.WriteString(` class="language-` + html.EscapeString(language) + `"`)
+	}
+	w.WriteString(">")
+	formatter := chromahtml.New(chromahtml.WithClasses(true), chromahtml.WithPreWrapper(noPreWrapper{}))
+	if err := formatter.Format(w, styles.Get(codeStyle), iterator); err != nil {
+		return ast.WalkStop, err
+	}
+	w.WriteString("</code></pre>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+// noPreWrapper omits chroma's <pre>, which renderCode writes itself.
+type noPreWrapper struct{}
+
+func (noPreWrapper) Start(code bool, styleAttr string) string { return "" }
+func (noPreWrapper) End(code bool) string                     { return "" }
+
+// highlightCSS returns the stylesheet for highlighted code.
+func highlightCSS() (string, error) {
+	var buf bytes.Buffer
+	formatter := chromahtml.New(chromahtml.WithClasses(true))
+	if err := formatter.WriteCSS(&buf, styles.Get(codeStyle)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
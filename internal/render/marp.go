@@ -0,0 +1,182 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/deck"
+	"github.com/agentplexus/agent-team-content/internal/theme"
+)
+
+// marpState holds the directives in effect for a slide.
+type marpState struct {
+	paginate        bool
+	header          string
+	footer          string
+	class           string
+	backgroundColor string
+	color           string
+}
+
+// apply sets a directive by name.
+func (m *marpState) apply(key, value string) {
+	switch key {
+	case "paginate":
+		m.paginate = value == "true"
+	case "header":
+		m.header = value
+	case "footer":
+		m.footer = value
+	case "class":
+		m.class = value
+	case "backgroundColor":
+		m.backgroundColor = value
+	case "color":
+		m.color = value
+	}
+}
+
+// bgImagePattern matches Marp's ![bg](url) background image syntax.
+var bgImagePattern = regexp.MustCompile(`!\[bg[^\]]*\]\(([^)\s]+)[^)]*\)`)
+
+// Marp renders a Marp deck as a standalone HTML page. Frontmatter and
+// comment directives are applied with Marp's semantics: local directives
+// carry over to following slides, _scoped ones apply to one slide only.
+func Marp(content string, opts Options) (string, error) {
+	d := deck.ParseMarp(content)
+	if d.FrontmatterErr != nil {
+		return "", fmt.Errorf("invalid frontmatter: %w", d.FrontmatterErr)
+	}
+
+	css, err := marpThemeCSS(d, opts)
+	if err != nil {
+		return "", err
+	}
+
+	width, height := 1280, 720
+	if size, _ := d.Directives["size"].(string); size == "4:3" {
+		width = 960
+	}
+
+	var global marpState
+	for key, value := range d.Directives {
+		global.apply(key, fmt.Sprint(value))
+	}
+
+	var slides strings.Builder
+	for i, s := range d.Slides {
+		local := global
+		for key, value := range s.Directives {
+			if scoped, ok := strings.CutPrefix(key, "_"); ok {
+				local.apply(scoped, value)
+				continue
+			}
+			global.apply(key, value)
+			local.apply(key, value)
+		}
+
+		section, err := marpSlide(s, i, local)
+		if err != nil {
+			return "", err
+		}
+		slides.WriteString(section)
+	}
+
+	lang, _ := d.Directives["lang"].(string)
+	return renderPage(page{
+		Title:   deckTitle(d),
+		Lang:    lang,
+		Class:   "deck marp",
+		Width:   width,
+		Height:  height,
+		Plugins: []string{"notes"},
+		Theme:   template.CSS(css),
+		Slides:  template.HTML(slides.String()),
+	})
+}
+
+// marpThemeCSS returns the theme stylesheet for a deck: the override theme
+// if given, otherwise the registered theme named by the theme directive or
+// the default theme, followed by any style directive.
+func marpThemeCSS(d *deck.Deck, opts Options) (string, error) {
+	name := opts.MarpTheme
+	if name == "" {
+		// Deck themes this renderer does not know fall back to the default
+		// theme; a style directive still applies on top.
+		name = "default"
+		if themes, err := theme.Discover(opts.ThemesDir); err == nil {
+			if t, err := themes.Find(FormatMarp, d.Theme()); err == nil && t.CSS != "" {
+				name = d.Theme()
+			}
+		}
+	}
+	css, err := loadTheme(FormatMarp, name, opts.ThemesDir)
+	if err != nil {
+		return "", err
+	}
+
+	if style, ok := d.Directives["style"].(string); ok {
+		css += "\n" + style
+	}
+	return css, nil
+}
+
+func marpSlide(s deck.Slide, index int, state marpState) (string, error) {
+	body := s.Body()
+
+	var styles []string
+	if m := bgImagePattern.FindStringSubmatch(body); m != nil {
+		styles = append(styles, fmt.Sprintf("background-image: url('%s'); background-size: cover; background-position: center", m[1]))
+		body = bgImagePattern.ReplaceAllString(body, "")
+	}
+	if state.backgroundColor != "" {
+		styles = append(styles, "background-color: "+state.backgroundColor)
+	}
+	if state.color != "" {
+		styles = append(styles, "color: "+state.color)
+	}
+
+	content, err := toHTML(body)
+	if err != nil {
+		return "", err
+	}
+	notes, err := notesHTML(s.Notes)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<section data-slide data-h="%d"`, index)
+	if state.class != "" {
+		fmt.Fprintf(&b, ` class="%s"`, html.EscapeString(state.class))
+	}
+	if state.paginate {
+		fmt.Fprintf(&b, ` data-marpit-pagination="%d"`, index+1)
+	}
+	if len(styles) > 0 {
+		fmt.Fprintf(&b, ` style="%s"`, html.EscapeString(strings.Join(styles, "; ")))
+	}
+	b.WriteString(">\n")
+
+	if state.header != "" {
+		header, err := inlineHTML(state.header)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString("<header>" + header + "</header>\n")
+	}
+	b.WriteString(content)
+	if state.footer != "" {
+		footer, err := inlineHTML(state.footer)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString("<footer>" + footer + "</footer>\n")
+	}
+	b.WriteString(notes)
+	b.WriteString("</section>\n")
+	return b.String(), nil
+}
@@ -0,0 +1,655 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// extractMath replaces TeX math between $ or $$ delimiters outside code with
+// placeholders that pass through Markdown unchanged, and returns the MathML
+// for each placeholder. As in pandoc, an opening $ must not be followed by a
+// space and a closing $ must not be preceded by one or followed by a digit,
+// so prices like $5 and $10 stay text.
+func extractMath(src string) (string, map[string]string) {
+	var (
+		out    strings.Builder
+		math   = map[string]string{}
+		fence  string
+		lines  = strings.SplitAfter(src, "\n")
+		buffer strings.Builder
+	)
+	flush := func() {
+		out.WriteString(replaceMath(buffer.String(), math))
+		buffer.Reset()
+	}
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			out.WriteString(line)
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+			fence = trimmed[:3]
+			out.WriteString(line)
+			continue
+		}
+		buffer.WriteString(line)
+	}
+	flush()
+	return out.String(), math
+}
+
+// replaceMath replaces the math in Markdown text without fenced code blocks,
+// skipping inline code spans.
+func replaceMath(src string, math map[string]string) string {
+	var out strings.Builder
+	for i := 0; i < len(src); {
+		switch {
+		case src[i] == '\\' && i+1 < len(src) && src[i+1] == '$':
+			out.WriteString(src[i : i+2])
+			i += 2
+		case src[i] == '`':
+			end := codeSpanEnd(src, i)
+			out.WriteString(src[i:end])
+			i = end
+		case strings.HasPrefix(src[i:], "$$"):
+			end := strings.Index(src[i+2:], "$$")
+			if end < 0 {
+				out.WriteString(src[i:])
+				return out.String()
+			}
+			tex := src[i+2 : i+2+end]
+			out.WriteString(mathPlaceholder(math, texToMathML(tex, true)))
+			i += 2 + end + 2
+		case src[i] == '$':
+			end := inlineMathEnd(src, i)
+			if end < 0 {
+				out.WriteByte('$')
+				i++
+				continue
+			}
+			out.WriteString(mathPlaceholder(math, texToMathML(src[i+1:end], false)))
+			i = end + 1
+		default:
+			out.WriteByte(src[i])
+			i++
+		}
+	}
+	return out.String()
+}
+
+// codeSpanEnd returns the index just past the code span starting at the
+// backticks at start, or just past the backticks if the span is not closed.
+func codeSpanEnd(src string, start int) int {
+	n := start
+	for n < len(src) && src[n] == '`' {
+		n++
+	}
+	ticks := src[start:n]
+	for i := n; i < len(src); {
+		j := strings.Index(src[i:], ticks)
+		if j < 0 {
+			break
+		}
+		k := i + j + len(ticks)
+		if k < len(src) && src[k] == '`' {
+			// A longer run of backticks does not close the span.
+			for k < len(src) && src[k] == '`' {
+				k++
+			}
+			i = k
+			continue
+		}
+		return k
+	}
+	return n
+}
+
+// inlineMathEnd returns the index of the $ closing the inline math opened at
+// start, or -1 if there is none on the same paragraph.
+func inlineMathEnd(src string, start int) int {
+	if start+1 >= len(src) || unicode.IsSpace(rune(src[start+1])) {
+		return -1
+	}
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '\n':
+			if i+1 < len(src) && src[i+1] == '\n' {
+				return -1
+			}
+		case '$':
+			if unicode.IsSpace(rune(src[i-1])) {
+				// Another opening $, as in "$5 and $10".
+				return -1
+			}
+			if i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9' {
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// mathPlaceholder records mathML and returns its placeholder, made of
+// private use characters that Markdown leaves alone.
+func mathPlaceholder(math map[string]string, mathML string) string {
+	key := fmt.Sprintf("\uE000math%d\uE001", len(math))
+	math[key] = mathML
+	return key
+}
+
+// restoreMath replaces the placeholders in rendered HTML with their MathML.
+func restoreMath(out string, math map[string]string) string {
+	for key, mathML := range math {
+		out = strings.ReplaceAll(out, key, mathML)
+	}
+	return out
+}
+
+// texToMathML converts a TeX math expression to MathML, which browsers
+// render natively. It covers the common subset of LaTeX math: scripts,
+// fractions, roots, Greek letters and symbols, functions, accents, fonts,
+// \left...\right delimiters, text and matrix environments. Unknown commands
+// are shown as errors.
+func texToMathML(tex string, display bool) string {
+	p := &texParser{src: []rune(tex)}
+	body := p.parseRow(nil)
+	attr := ""
+	if display {
+		attr = ` display="block"`
+	}
+	return fmt.Sprintf(`<math%s><semantics>%s<annotation encoding="application/x-tex">%s</annotation></semantics></math>`,
+		attr, row(body), html.EscapeString(strings.TrimSpace(tex)))
+}
+
+// texNode is a converted piece of math. limits marks large operators whose
+// scripts go above and below.
+type texNode struct {
+	mathML string
+	limits bool
+}
+
+type texParser struct {
+	src []rune
+	pos int
+}
+
+// row wraps nodes in an mrow unless there is exactly one.
+func row(nodes []texNode) string {
+	if len(nodes) == 1 {
+		return nodes[0].mathML
+	}
+	var b strings.Builder
+	b.WriteString("<mrow>")
+	for _, n := range nodes {
+		b.WriteString(n.mathML)
+	}
+	b.WriteString("</mrow>")
+	return b.String()
+}
+
+// parseRow parses atoms with their scripts until the end of input, a closing
+// brace, or a command for which stop returns true. The stopping token is not
+// consumed.
+func (p *texParser) parseRow(stop func(token string) bool) []texNode {
+	var nodes []texNode
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) || p.src[p.pos] == '}' {
+			return nodes
+		}
+		if stop != nil && stop(p.peekToken()) {
+			return nodes
+		}
+		var base texNode
+		switch p.src[p.pos] {
+		case '^', '_':
+			base = texNode{mathML: "<mrow></mrow>"}
+		default:
+			var ok bool
+			if base, ok = p.parseAtom(); !ok {
+				continue
+			}
+		}
+		nodes = append(nodes, p.parseScripts(base))
+	}
+}
+
+// peekToken returns the next token: a command with its backslash, or a
+// single character.
+func (p *texParser) peekToken() string {
+	if p.src[p.pos] != '\\' {
+		return string(p.src[p.pos])
+	}
+	end := p.pos + 1
+	for end < len(p.src) && isLetter(p.src[end]) {
+		end++
+	}
+	if end == p.pos+1 && end < len(p.src) {
+		end++
+	}
+	return string(p.src[p.pos:end])
+}
+
+func (p *texParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func isLetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// parseScripts attaches any sub- and superscripts following base.
+func (p *texParser) parseScripts(base texNode) texNode {
+	var sub, sup string
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			break
+		}
+		switch p.src[p.pos] {
+		case '_':
+			p.pos++
+			sub = p.parseArgument()
+			continue
+		case '^':
+			p.pos++
+			sup = p.parseArgument()
+			continue
+		case '\'':
+			p.pos++
+			sup += "<mo>′</mo>"
+			continue
+		}
+		break
+	}
+	under, over, both := "msub", "msup", "msubsup"
+	if base.limits {
+		under, over, both = "munder", "mover", "munderover"
+	}
+	switch {
+	case sub != "" && sup != "":
+		return texNode{mathML: fmt.Sprintf("<%s>%s%s%s</%s>", both, base.mathML, sub, sup, both)}
+	case sub != "":
+		return texNode{mathML: fmt.Sprintf("<%s>%s%s</%s>", under, base.mathML, sub, under)}
+	case sup != "":
+		return texNode{mathML: fmt.Sprintf("<%s>%s%s</%s>", over, base.mathML, sup, over)}
+	}
+	return base
+}
+
+// parseArgument parses a braced group or a single atom, as the argument of
+// a command or script.
+func (p *texParser) parseArgument() string {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return "<mrow></mrow>"
+	}
+	if p.src[p.pos] == '{' {
+		return row(p.parseGroup())
+	}
+	for p.pos < len(p.src) {
+		if atom, ok := p.parseAtom(); ok {
+			return atom.mathML
+		}
+	}
+	return "<mrow></mrow>"
+}
+
+// parseGroup parses the contents of a braced group.
+func (p *texParser) parseGroup() []texNode {
+	p.pos++ // {
+	nodes := p.parseRow(nil)
+	if p.pos < len(p.src) {
+		p.pos++ // }
+	}
+	return nodes
+}
+
+// rawGroup returns the text of a braced group without converting it.
+func (p *texParser) rawGroup() string {
+	p.skipSpace()
+	if p.pos >= len(p.src) || p.src[p.pos] != '{' {
+		return ""
+	}
+	depth := 0
+	start := p.pos + 1
+	for ; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				text := string(p.src[start:p.pos])
+				p.pos++
+				return text
+			}
+		}
+	}
+	return string(p.src[start:])
+}
+
+// parseAtom parses one atom. It returns false for input that produces no
+// MathML, such as a stray closing brace or alignment character.
+func (p *texParser) parseAtom() (texNode, bool) {
+	r := p.src[p.pos]
+	switch {
+	case r == '{':
+		return texNode{mathML: row(p.parseGroup())}, true
+	case r == '\\':
+		return p.parseCommand()
+	case r >= '0' && r <= '9' || r == '.' && p.pos+1 < len(p.src) && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9':
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		return texNode{mathML: "<mn>" + string(p.src[start:p.pos]) + "</mn>"}, true
+	case unicode.IsLetter(r):
+		p.pos++
+		return texNode{mathML: "<mi>" + html.EscapeString(string(r)) + "</mi>"}, true
+	case r == '&' || r == '}' || r == '~':
+		p.pos++
+		return texNode{}, false
+	}
+	p.pos++
+	op := string(r)
+	switch r {
+	case '-':
+		op = "−"
+	case '*':
+		op = "∗"
+	}
+	return texNode{mathML: "<mo>" + html.EscapeString(op) + "</mo>"}, true
+}
+
+// parseCommand parses a command starting with a backslash.
+func (p *texParser) parseCommand() (texNode, bool) {
+	token := p.peekToken()
+	p.pos += len([]rune(token))
+	name := token[1:]
+
+	if s, ok := texSpaces[name]; ok {
+		return texNode{mathML: `<mspace width="` + s + `"></mspace>`}, true
+	}
+	if s, ok := texIdentifiers[name]; ok {
+		return texNode{mathML: "<mi>" + s + "</mi>"}, true
+	}
+	if s, ok := texOperators[name]; ok {
+		return texNode{mathML: "<mo>" + html.EscapeString(s) + "</mo>"}, true
+	}
+	if s, ok := texLargeOperators[name]; ok {
+		return texNode{mathML: "<mo>" + s + "</mo>", limits: s != "∫" && s != "∬" && s != "∮"}, true
+	}
+	if texFunctions[name] {
+		return texNode{mathML: "<mi>" + name + "</mi>", limits: texLimitFunctions[name]}, true
+	}
+	if s, ok := texAccents[name]; ok {
+		return texNode{mathML: `<mover accent="true">` + p.parseArgument() + "<mo>" + s + "</mo></mover>"}, true
+	}
+
+	switch name {
+	case "frac", "dfrac", "tfrac", "cfrac":
+		num := p.parseArgument()
+		den := p.parseArgument()
+		return texNode{mathML: "<mfrac>" + num + den + "</mfrac>"}, true
+	case "binom":
+		top := p.parseArgument()
+		bottom := p.parseArgument()
+		return texNode{mathML: `<mrow><mo>(</mo><mfrac linethickness="0">` + top + bottom + "</mfrac><mo>)</mo></mrow>"}, true
+	case "sqrt":
+		p.skipSpace()
+		if p.pos < len(p.src) && p.src[p.pos] == '[' {
+			p.pos++
+			index := row(p.parseRow(func(token string) bool { return token == "]" }))
+			if p.pos < len(p.src) {
+				p.pos++ // ]
+			}
+			return texNode{mathML: "<mroot>" + p.parseArgument() + index + "</mroot>"}, true
+		}
+		return texNode{mathML: "<msqrt>" + p.parseArgument() + "</msqrt>"}, true
+	case "text", "textrm", "mbox":
+		return texNode{mathML: "<mtext>" + html.EscapeString(p.rawGroup()) + "</mtext>"}, true
+	case "mathrm", "operatorname":
+		return texNode{mathML: styledLetters(p.rawGroup(), `<mi mathvariant="normal">`, func(s string) string { return s })}, true
+	case "mathbf", "boldsymbol":
+		return texNode{mathML: styledLetters(p.rawGroup(), "<mi>", func(s string) string { return mapAlphabet(s, 0x1D400, nil) })}, true
+	case "mathbb":
+		return texNode{mathML: styledLetters(p.rawGroup(), "<mi>", func(s string) string { return mapAlphabet(s, 0x1D538, doubleStruck) })}, true
+	case "mathit", "mathcal", "mathsf", "mathtt", "mathscr", "mathfrak":
+		return texNode{mathML: p.parseArgument()}, true
+	case "overline":
+		return texNode{mathML: `<mover accent="true">` + p.parseArgument() + "<mo>‾</mo></mover>"}, true
+	case "underline":
+		return texNode{mathML: `<munder accentunder="true">` + p.parseArgument() + "<mo>_</mo></munder>"}, true
+	case "left":
+		open := p.delimiter()
+		body := p.parseRow(func(token string) bool { return token == `\right` })
+		close := ""
+		if p.pos < len(p.src) {
+			p.pos += len(`\right`)
+			close = p.delimiter()
+		}
+		return texNode{mathML: "<mrow>" + open + row(body) + close + "</mrow>"}, true
+	case "right":
+		p.delimiter()
+		return texNode{}, false
+	case "begin":
+		return texNode{mathML: p.parseEnvironment(p.rawGroup())}, true
+	case "end":
+		p.rawGroup()
+		return texNode{}, false
+	case "\\":
+		return texNode{}, false
+	}
+	if len(name) == 1 && !isLetter(rune(name[0])) {
+		// An escaped character, such as \{ or \%.
+		return texNode{mathML: "<mo>" + html.EscapeString(name) + "</mo>"}, true
+	}
+	return texNode{mathML: "<merror><mtext>" + html.EscapeString(token) + "</mtext></merror>"}, true
+}
+
+// delimiter parses the delimiter after \left or \right; "." is none.
+func (p *texParser) delimiter() string {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return ""
+	}
+	token := p.peekToken()
+	p.pos += len([]rune(token))
+	switch token {
+	case ".":
+		return ""
+	case `\{`:
+		return "<mo>{</mo>"
+	case `\}`:
+		return "<mo>}</mo>"
+	case `\|`:
+		return "<mo>‖</mo>"
+	}
+	if s, ok := texOperators[strings.TrimPrefix(token, `\`)]; ok && strings.HasPrefix(token, `\`) {
+		return "<mo>" + s + "</mo>"
+	}
+	return "<mo>" + html.EscapeString(token) + "</mo>"
+}
+
+// texMatrices are the delimiters of the matrix environments.
+var texMatrices = map[string][2]string{
+	"matrix":  {"", ""},
+	"pmatrix": {"(", ")"},
+	"bmatrix": {"[", "]"},
+	"Bmatrix": {"{", "}"},
+	"vmatrix": {"|", "|"},
+	"Vmatrix": {"‖", "‖"},
+	"cases":   {"{", ""},
+	"aligned": {"", ""},
+	"align":   {"", ""},
+	"align*":  {"", ""},
+	"array":   {"", ""},
+}
+
+// parseEnvironment parses a matrix-like environment up to its \end, with
+// rows separated by \\ and cells by &.
+func (p *texParser) parseEnvironment(env string) string {
+	if env == "array" {
+		p.rawGroup() // column specification
+	}
+	isEnd := func(token string) bool { return token == "&" || token == `\\` || token == `\end` }
+	var rows strings.Builder
+	var cells []string
+	for {
+		cells = append(cells, row(p.parseRow(isEnd)))
+		if p.pos >= len(p.src) || p.src[p.pos] == '}' {
+			break
+		}
+		token := p.peekToken()
+		p.pos += len([]rune(token))
+		if token == "&" {
+			continue
+		}
+		// A \\ before \end does not start another row.
+		if token != `\end` || len(cells) > 1 || cells[0] != "<mrow></mrow>" {
+			rows.WriteString(tableRow(cells))
+		}
+		cells = nil
+		if token == `\end` {
+			p.rawGroup()
+			break
+		}
+	}
+	if cells != nil && !(len(cells) == 1 && cells[0] == "<mrow></mrow>") {
+		rows.WriteString(tableRow(cells))
+	}
+
+	table := "<mtable>" + rows.String() + "</mtable>"
+	if env == "cases" || env == "aligned" || env == "align" || env == "align*" {
+		table = `<mtable columnalign="left">` + rows.String() + "</mtable>"
+	}
+	delims, ok := texMatrices[env]
+	if !ok {
+		return "<merror><mtext>" + html.EscapeString(env) + "</mtext></merror>" + table
+	}
+	var b strings.Builder
+	b.WriteString("<mrow>")
+	if delims[0] != "" {
+		b.WriteString("<mo>" + html.EscapeString(delims[0]) + "</mo>")
+	}
+	b.WriteString(table)
+	if delims[1] != "" {
+		b.WriteString("<mo>" + html.EscapeString(delims[1]) + "</mo>")
+	}
+	b.WriteString("</mrow>")
+	return b.String()
+}
+
+func tableRow(cells []string) string {
+	var b strings.Builder
+	b.WriteString("<mtr>")
+	for _, c := range cells {
+		b.WriteString("<mtd>" + c + "</mtd>")
+	}
+	b.WriteString("</mtr>")
+	return b.String()
+}
+
+// styledLetters returns a font command's argument as one identifier in the
+// font given by style, or converts it unstyled if it is more than letters and
+// digits.
+func styledLetters(text, open string, style func(string) string) string {
+	for _, r := range text {
+		if !isLetter(r) && !(r >= '0' && r <= '9') && r != ' ' {
+			return row((&texParser{src: []rune(text)}).parseRow(nil))
+		}
+	}
+	return open + html.EscapeString(style(text)) + "</mi>"
+}
+
+// doubleStruck holds the double-struck capitals outside the Mathematical
+// Alphanumeric Symbols block.
+var doubleStruck = map[rune]rune{'C': 'ℂ', 'H': 'ℍ', 'N': 'ℕ', 'P': 'ℙ', 'Q': 'ℚ', 'R': 'ℝ', 'Z': 'ℤ'}
+
+// mapAlphabet maps ASCII letters to a Unicode math alphabet starting at
+// capitalA, with lowercase letters following the capitals.
+func mapAlphabet(s string, capitalA rune, exceptions map[rune]rune) string {
+	return strings.Map(func(r rune) rune {
+		if e, ok := exceptions[r]; ok {
+			return e
+		}
+		switch {
+		case r >= 'A' && r <= 'Z':
+			return capitalA + r - 'A'
+		case r >= 'a' && r <= 'z':
+			return capitalA + 26 + r - 'a'
+		}
+		return r
+	}, s)
+}
+
+var texSpaces = map[string]string{
+	",": "0.167em", ":": "0.222em", ">": "0.222em", ";": "0.278em", "!": "-0.167em",
+	" ": "0.25em", "quad": "1em", "qquad": "2em",
+}
+
+var texIdentifiers = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ", "varepsilon": "ε",
+	"zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ", "iota": "ι", "kappa": "κ",
+	"lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ", "pi": "π", "varpi": "ϖ", "rho": "ρ",
+	"varrho": "ϱ", "sigma": "σ", "varsigma": "ς", "tau": "τ", "upsilon": "υ", "phi": "ϕ",
+	"varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π",
+	"Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+	"infty": "∞", "partial": "∂", "nabla": "∇", "emptyset": "∅", "varnothing": "∅",
+	"ell": "ℓ", "hbar": "ℏ", "Re": "ℜ", "Im": "ℑ", "aleph": "ℵ",
+}
+
+var texOperators = map[string]string{
+	"cdot": "⋅", "times": "×", "div": "÷", "pm": "±", "mp": "∓", "ast": "∗", "star": "⋆",
+	"circ": "∘", "bullet": "∙", "le": "≤", "leq": "≤", "ge": "≥", "geq": "≥", "ne": "≠",
+	"neq": "≠", "ll": "≪", "gg": "≫", "approx": "≈", "equiv": "≡", "sim": "∼", "simeq": "≃",
+	"cong": "≅", "propto": "∝", "to": "→", "rightarrow": "→", "leftarrow": "←",
+	"gets": "←", "leftrightarrow": "↔", "Rightarrow": "⇒", "Leftarrow": "⇐",
+	"Leftrightarrow": "⇔", "implies": "⟹", "iff": "⟺", "mapsto": "↦", "uparrow": "↑",
+	"downarrow": "↓", "in": "∈", "notin": "∉", "ni": "∋", "subset": "⊂", "subseteq": "⊆",
+	"supset": "⊃", "supseteq": "⊇", "cup": "∪", "cap": "∩", "setminus": "∖",
+	"forall": "∀", "exists": "∃", "nexists": "∄", "neg": "¬", "lnot": "¬", "land": "∧",
+	"wedge": "∧", "lor": "∨", "vee": "∨", "oplus": "⊕", "otimes": "⊗", "perp": "⊥",
+	"parallel": "∥", "mid": "∣", "angle": "∠", "triangle": "△", "prime": "′",
+	"ldots": "…", "dots": "…", "cdots": "⋯", "vdots": "⋮", "ddots": "⋱",
+	"langle": "⟨", "rangle": "⟩", "lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉",
+	"vert": "|", "Vert": "‖", "lbrace": "{", "rbrace": "}",
+}
+
+var texLargeOperators = map[string]string{
+	"sum": "∑", "prod": "∏", "coprod": "∐", "int": "∫", "iint": "∬", "oint": "∮",
+	"bigcup": "⋃", "bigcap": "⋂", "bigoplus": "⨁", "bigotimes": "⨂",
+}
+
+var texFunctions = map[string]bool{
+	"sin": true, "cos": true, "tan": true, "cot": true, "sec": true, "csc": true,
+	"arcsin": true, "arccos": true, "arctan": true, "sinh": true, "cosh": true, "tanh": true,
+	"log": true, "ln": true, "lg": true, "exp": true, "lim": true, "max": true, "min": true,
+	"sup": true, "inf": true, "det": true, "gcd": true, "deg": true, "dim": true,
+	"ker": true, "arg": true, "Pr": true, "argmax": true, "argmin": true,
+}
+
+// texLimitFunctions take their subscripts below, as in \lim_{x \to 0}.
+var texLimitFunctions = map[string]bool{
+	"lim": true, "max": true, "min": true, "sup": true, "inf": true, "det": true,
+	"gcd": true, "Pr": true, "argmax": true, "argmin": true,
+}
+
+var texAccents = map[string]string{
+	"hat": "^", "widehat": "^", "bar": "¯", "vec": "→", "dot": "˙", "ddot": "¨",
+	"tilde": "~", "widetilde": "~", "check": "ˇ", "acute": "´", "grave": "`", "breve": "˘",
+}
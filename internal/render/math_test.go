@@ -0,0 +1,65 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTeXToMathML(t *testing.T) {
+	tests := []struct {
+		tex  string
+		want string
+	}{
+		{`x^2 + y_i`, `<mrow><msup><mi>x</mi><mn>2</mn></msup><mo>+</mo><msub><mi>y</mi><mi>i</mi></msub></mrow>`},
+		{`\frac{a}{2}`, `<mfrac><mi>a</mi><mn>2</mn></mfrac>`},
+		{`\sqrt{x}`, `<msqrt><mi>x</mi></msqrt>`},
+		{`\sqrt[3]{x}`, `<mroot><mi>x</mi><mn>3</mn></mroot>`},
+		{`\sum_{i=1}^n i`, `<mrow><munderover><mo>∑</mo><mrow><mi>i</mi><mo>=</mo><mn>1</mn></mrow><mi>n</mi></munderover><mi>i</mi></mrow>`},
+		{`\int_0^1`, `<msubsup><mo>∫</mo><mn>0</mn><mn>1</mn></msubsup>`},
+		{`\alpha \le \beta`, `<mrow><mi>α</mi><mo>≤</mo><mi>β</mi></mrow>`},
+		{`\left( x \right)`, `<mrow><mo>(</mo><mi>x</mi><mo>)</mo></mrow>`},
+		{`\mathbb{R}`, `<mi>ℝ</mi>`},
+		{`\text{if } x`, `<mrow><mtext>if </mtext><mi>x</mi></mrow>`},
+		{`f'`, `<msup><mi>f</mi><mo>′</mo></msup>`},
+		{`a - b`, `<mrow><mi>a</mi><mo>−</mo><mi>b</mi></mrow>`},
+		{`\begin{pmatrix} 1 & 2 \\ 3 & 4 \end{pmatrix}`, `<mrow><mo>(</mo><mtable><mtr><mtd><mn>1</mn></mtd><mtd><mn>2</mn></mtd></mtr><mtr><mtd><mn>3</mn></mtd><mtd><mn>4</mn></mtd></mtr></mtable><mo>)</mo></mrow>`},
+		{`\unknown`, `<merror><mtext>\unknown</mtext></merror>`},
+		{`x < y`, `<mrow><mi>x</mi><mo>&lt;</mo><mi>y</mi></mrow>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tex, func(t *testing.T) {
+			got := texToMathML(tt.tex, false)
+			if !strings.Contains(got, "<semantics>"+tt.want+"<annotation") {
+				t.Errorf("texToMathML(%q) = %s, want %s", tt.tex, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractMath(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		math int
+	}{
+		{"inline", "Euler: $e^{i\\pi} = -1$.", 1},
+		{"display", "$$\nx^2\n$$", 1},
+		{"prices", "It costs $5 and $10.", 0},
+		{"space after opening", "a $ b$", 0},
+		{"escaped dollar", "\\$x$", 0},
+		{"code span", "`$x$` and $y$", 1},
+		{"fenced code", "```\n$x$\n```\n$y$", 1},
+		{"across paragraphs", "$x\n\ny$", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, math := extractMath(tt.src)
+			if len(math) != tt.math {
+				t.Errorf("extractMath(%q) found %d formulas in %q, want %d", tt.src, len(math), out, tt.math)
+			}
+			if got := restoreMath(out, map[string]string{}); len(math) == 0 && got != tt.src {
+				t.Errorf("extractMath(%q) changed text without math: %q", tt.src, got)
+			}
+		})
+	}
+}
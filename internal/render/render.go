@@ -0,0 +1,295 @@
+// Package render turns Marp and Reveal.js Markdown decks into self-contained
+// HTML presentations that open offline, or into PowerPoint files.
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/deck"
+	"github.com/agentplexus/agent-team-content/internal/pptx"
+	"github.com/agentplexus/agent-team-content/internal/theme"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Formats supported by Render.
+const (
+	FormatMarp   = "marp"
+	FormatReveal = "revealjs"
+)
+
+//go:embed assets/player.css assets/player.js
+var assets embed.FS
+
+// Output targets.
+const (
+	TargetHTML = "html"
+	TargetPPTX = "pptx"
+)
+
+// Options configures rendering.
+type Options struct {
+	// MarpTheme is a built-in Marp theme name or a path to a theme CSS file.
+	// It overrides the deck's theme directive when set.
+	MarpTheme string
+	// RevealTheme is a built-in Reveal.js theme name or a path to a theme CSS
+	// file. It overrides the deck's theme when set.
+	RevealTheme string
+	// ThemesDir holds theme CSS files that MarpTheme and RevealTheme may
+	// name, in addition to the built-in themes.
+	ThemesDir string
+	// PPTXTemplate is a .pptx file whose theme colors and fonts are used for
+	// PowerPoint output.
+	PPTXTemplate string
+}
+
+// Detect returns the format of a deck from its file name, falling back to
+// FormatMarp when the content has Marp frontmatter. It returns "" if the
+// format cannot be determined.
+func Detect(file, content string) string {
+	switch strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)) {
+	case "marp":
+		return FormatMarp
+	case "revealjs":
+		return FormatReveal
+	}
+	if marp, _ := deck.ParseMarp(content).Directives["marp"].(bool); marp {
+		return FormatMarp
+	}
+	return ""
+}
+
+// OutputFile returns the file name for a deck file rendered to target, e.g.
+// marp.html for marp.md.
+func OutputFile(file, target string) string {
+	return strings.TrimSuffix(file, filepath.Ext(file)) + "." + target
+}
+
+// CheckTargets returns an error naming the first unknown output target.
+func CheckTargets(targets []string) error {
+	for _, target := range targets {
+		if target != TargetHTML && target != TargetPPTX {
+			return fmt.Errorf("unknown render target: %q (expected %s or %s)", target, TargetHTML, TargetPPTX)
+		}
+	}
+	return nil
+}
+
+// To renders a deck in the given format to an output target.
+func To(target, format, content string, opts Options) ([]byte, error) {
+	switch target {
+	case TargetHTML:
+		page, err := Render(format, content, opts)
+		return []byte(page), err
+	case TargetPPTX:
+		return PPTX(format, content, opts)
+	default:
+		return nil, fmt.Errorf("unknown render target: %q", target)
+	}
+}
+
+// PPTX exports a deck in the given format as a PowerPoint file.
+func PPTX(format, content string, opts Options) ([]byte, error) {
+	var d *deck.Deck
+	switch format {
+	case FormatMarp:
+		d = deck.ParseMarp(content)
+	case FormatReveal:
+		d = deck.ParseReveal(content)
+	default:
+		return nil, fmt.Errorf("unknown presentation format: %q", format)
+	}
+
+	var pptxOpts pptx.Options
+	if opts.PPTXTemplate != "" {
+		template, err := os.ReadFile(opts.PPTXTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		pptxOpts.Template = template
+	}
+
+	var buf bytes.Buffer
+	if err := pptx.Write(&buf, pptx.FromDeck(d), pptxOpts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render renders a deck in the given format as a standalone HTML page.
+func Render(format, content string, opts Options) (string, error) {
+	switch format {
+	case FormatMarp:
+		return Marp(content, opts)
+	case FormatReveal:
+		return Reveal(content, opts)
+	default:
+		return "", fmt.Errorf("unknown presentation format: %q", format)
+	}
+}
+
+// page is the data for the HTML page template.
+type page struct {
+	Title  string
+	Lang   string
+	Class  string
+	Width  int
+	Height int
+	// Transition animates slides as they appear: fade, slide, convex,
+	// concave or zoom.
+	Transition  string
+	SlideNumber bool
+	// Plugins are the player features to enable: notes, search and zoom.
+	Plugins []string
+	// Config is written as JSON for use with reveal.js itself.
+	Config any
+	Player template.CSS
+	// Code styles highlighted code blocks.
+	Code   template.CSS
+	Theme  template.CSS
+	Slides template.HTML
+	Script template.JS
+}
+
+var pageTemplate = template.Must(template.New("page").Funcs(template.FuncMap{"join": strings.Join}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+{{.Player}}
+</style>
+{{with .Code}}<style>
+{{.}}
+</style>
+{{end}}<style>
+{{.Theme}}
+</style>
+</head>
+<body>
+<div class="{{.Class}}" data-width="{{.Width}}" data-height="{{.Height}}"{{with .Transition}} data-transition="{{.}}"{{end}}{{if .SlideNumber}} data-slide-number{{end}} data-plugins="{{join .Plugins " "}}">
+{{.Slides}}</div>
+<div class="notes-panel" hidden></div>
+<div class="slide-number" hidden></div>
+<div class="progress"></div>
+{{with .Config}}<script type="application/json" class="reveal-config">{{.}}</script>
+{{end}}<script>
+{{.Script}}
+</script>
+</body>
+</html>
+`))
+
+func renderPage(p page) (string, error) {
+	css, err := assets.ReadFile("assets/player.css")
+	if err != nil {
+		return "", err
+	}
+	js, err := assets.ReadFile("assets/player.js")
+	if err != nil {
+		return "", err
+	}
+	p.Player = template.CSS(css)
+	p.Theme = escapeCSS(p.Theme)
+	p.Script = template.JS(js)
+	if p.Lang == "" {
+		p.Lang = "en"
+	}
+
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, p); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// escapeCSS escapes "</" in a stylesheet, so CSS from a theme file or a
+// deck's style directive cannot close the <style> element it is inlined in.
+// "<\/" is the same in CSS, where a backslash escapes the character after it.
+func escapeCSS(css template.CSS) template.CSS {
+	return template.CSS(strings.ReplaceAll(string(css), "</", `<\/`))
+}
+
+// markdown renders slide Markdown. Raw HTML is omitted, as in marp-cli's
+// default configuration.
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// highlighted renders slide Markdown with syntax highlighted code blocks.
+var highlighted = goldmark.New(goldmark.WithExtensions(extension.GFM, highlightExtension{}))
+
+func toHTML(src string) (string, error) {
+	return convert(markdown, src)
+}
+
+func convert(md goldmark.Markdown, src string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// inlineHTML renders a single line of Markdown without the surrounding
+// paragraph.
+func inlineHTML(src string) (string, error) {
+	out, err := toHTML(src)
+	if err != nil {
+		return "", err
+	}
+	out = strings.TrimSpace(out)
+	out = strings.TrimPrefix(out, "<p>")
+	return strings.TrimSuffix(out, "</p>"), nil
+}
+
+// notesHTML renders speaker notes as an aside, or "" if there are none.
+func notesHTML(notes []string) (string, error) {
+	if len(notes) == 0 {
+		return "", nil
+	}
+	out, err := toHTML(strings.Join(notes, "\n\n"))
+	if err != nil {
+		return "", err
+	}
+	return `<aside class="notes">` + out + "</aside>\n", nil
+}
+
+// loadTheme resolves a theme given as a CSS file path or a theme name
+// registered in the built-in themes or themesDir.
+func loadTheme(format, name, themesDir string) (string, error) {
+	themes, err := theme.Discover(themesDir)
+	if err != nil {
+		return "", err
+	}
+	t, err := themes.Resolve(format, name)
+	if err != nil {
+		return "", err
+	}
+	if t.CSS == "" {
+		return "", fmt.Errorf("%s theme %q is not bundled; pass its CSS file instead", format, name)
+	}
+	return t.CSS, nil
+}
+
+// headingPattern matches a Markdown ATX heading.
+var headingPattern = regexp.MustCompile(`(?m)^#{1,6}\s+(.+?)\s*#*\s*$`)
+
+// deckTitle returns the deck's title directive or its first heading.
+func deckTitle(d *deck.Deck) string {
+	if title, ok := d.Directives["title"].(string); ok && title != "" {
+		return title
+	}
+	for _, s := range d.Slides {
+		if m := headingPattern.FindStringSubmatch(s.Body()); m != nil {
+			return m[1]
+		}
+	}
+	return "Presentation"
+}
@@ -0,0 +1,160 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+
+	"github.com/agentplexus/agent-team-content/internal/deck"
+	"github.com/yuin/goldmark"
+)
+
+// Reveal renders a Reveal.js Markdown deck as a standalone HTML page using
+// Reveal.js section markup, so Reveal.js themes apply unchanged. Vertical
+// slides are grouped into stacks. The theme, transition and slide numbering
+// are taken from reveal-md style frontmatter, and the deck's reveal.js
+// configuration is embedded as JSON. The highlight plugin highlights code
+// and steps through line highlights, the math plugin renders TeX as MathML,
+// and the notes, search and zoom plugins enable those player features.
+func Reveal(content string, opts Options) (string, error) {
+	d := deck.ParseReveal(content)
+
+	css, err := revealThemeCSS(d, opts)
+	if err != nil {
+		return "", err
+	}
+	config := revealConfig(d)
+
+	md := markdown
+	var codeCSS string
+	if slices.Contains(config.Plugins, "highlight") {
+		md = highlighted
+		if codeCSS, err = highlightCSS(); err != nil {
+			return "", err
+		}
+	}
+	math := slices.Contains(config.Plugins, "math")
+
+	var (
+		slides  strings.Builder
+		column  = -1
+		stacked bool
+	)
+	for i, s := range d.Slides {
+		// A slide followed by vertical slides opens a stack.
+		opensStack := i+1 < len(d.Slides) && d.Slides[i+1].Vertical
+		if !s.Vertical {
+			if stacked {
+				slides.WriteString("</section>\n")
+				stacked = false
+			}
+			column++
+			if opensStack {
+				slides.WriteString(`<section class="stack">` + "\n")
+				stacked = true
+			}
+		}
+
+		body, err := slideHTML(md, s.Body(), math)
+		if err != nil {
+			return "", err
+		}
+		notes, err := notesHTML(s.Notes)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&slides, `<section data-slide data-h="%d">`+"\n%s%s</section>\n", column, body, notes)
+	}
+	if stacked {
+		slides.WriteString("</section>\n")
+	}
+
+	return renderPage(page{
+		Title:       deckTitle(d),
+		Class:       "deck reveal",
+		Width:       960,
+		Height:      700,
+		Transition:  config.Transition,
+		SlideNumber: config.SlideNumber,
+		Plugins:     config.Plugins,
+		Config:      config,
+		Code:        template.CSS(codeCSS),
+		Theme:       template.CSS(css),
+		Slides:      template.HTML(`<div class="slides">` + "\n" + slides.String() + "</div>\n"),
+	})
+}
+
+// slideHTML renders a slide's Markdown with md, converting TeX math to
+// MathML first if math is set.
+func slideHTML(md goldmark.Markdown, src string, math bool) (string, error) {
+	if !math {
+		return convert(md, src)
+	}
+	src, formulas := extractMath(src)
+	out, err := convert(md, src)
+	if err != nil {
+		return "", err
+	}
+	return restoreMath(out, formulas), nil
+}
+
+// revealThemeCSS returns the stylesheet for a deck: the override theme if
+// set, otherwise the named theme (default black), followed by any CSS in
+// the frontmatter's style key. A style key on a theme this renderer does
+// not know is taken to be the whole theme.
+func revealThemeCSS(d *deck.Deck, opts Options) (string, error) {
+	name, _ := d.Directives["theme"].(string)
+	if opts.RevealTheme != "" {
+		name = opts.RevealTheme
+	} else if name == "" {
+		name = "black"
+	}
+	style, ok := d.Directives["style"].(string)
+	if !ok {
+		return loadTheme(FormatReveal, name, opts.ThemesDir)
+	}
+	css, err := loadTheme(FormatReveal, name, opts.ThemesDir)
+	if err != nil {
+		if opts.RevealTheme != "" {
+			return "", err
+		}
+		return style, nil
+	}
+	return css + "\n" + style, nil
+}
+
+// revealOptions is the subset of the reveal.js configuration the player
+// supports, plus the plugins to load.
+type revealOptions struct {
+	Transition  string   `json:"transition"`
+	SlideNumber bool     `json:"slideNumber"`
+	Plugins     []string `json:"plugins,omitempty"`
+}
+
+// defaultRevealPlugins are the plugins for a deck without a plugins key,
+// the same as the revealjs agent's defaults.
+var defaultRevealPlugins = []string{"notes", "highlight"}
+
+// revealConfig reads the deck's revealOptions and plugins frontmatter keys.
+// The transition defaults to slide, as in reveal.js.
+func revealConfig(d *deck.Deck) revealOptions {
+	config := revealOptions{Transition: "slide"}
+	if options, ok := d.Directives["revealOptions"].(map[string]any); ok {
+		if transition, ok := options["transition"].(string); ok && transition != "" {
+			config.Transition = transition
+		}
+		config.SlideNumber, _ = options["slideNumber"].(bool)
+	}
+	plugins, ok := d.Directives["plugins"].([]any)
+	if !ok {
+		config.Plugins = defaultRevealPlugins
+		return config
+	}
+	for _, plugin := range plugins {
+		if name, ok := plugin.(string); ok {
+			config.Plugins = append(config.Plugins, name)
+		}
+	}
+	return config
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/agent-team-content/internal/agent"
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+)
+
+// Job statuses.
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusCanceled  = "canceled"
+)
+
+// Event types sent on a job's progress stream.
+const (
+	EventStatus = "status"
+	EventResult = "result"
+)
+
+// Event is a single progress update for a job.
+type Event struct {
+	Type   string      `json:"type"`
+	Status string      `json:"status,omitempty"`
+	Output *OutputView `json:"output,omitempty"`
+}
+
+// Job is a content generation request tracked by the server.
+type Job struct {
+	mu sync.Mutex
+
+	id           string
+	agents       []string
+	orchestrator *agent.Orchestrator
+	conv         *conversation.Conversation
+
+	status   string
+	err      string
+	created  time.Time
+	started  time.Time
+	finished time.Time
+	results  map[string]agent.Result
+
+	events  []Event
+	changed chan struct{}
+}
+
+// JobView is the JSON representation of a job.
+type JobView struct {
+	ID         string       `json:"id"`
+	Status     string       `json:"status"`
+	Agents     []string     `json:"agents"`
+	Error      string       `json:"error,omitempty"`
+	CreatedAt  string       `json:"created_at"`
+	StartedAt  string       `json:"started_at,omitempty"`
+	FinishedAt string       `json:"finished_at,omitempty"`
+	Outputs    []OutputView `json:"outputs"`
+}
+
+// OutputView describes the result of one agent within a job.
+type OutputView struct {
+	Agent       string             `json:"agent"`
+	File        string             `json:"file,omitempty"`
+	URL         string             `json:"url,omitempty"`
+	Artifacts   []ArtifactView     `json:"artifacts,omitempty"`
+	Diagnostics []agent.Diagnostic `json:"diagnostics,omitempty"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// ArtifactView describes an additional file produced by an agent.
+type ArtifactView struct {
+	File string `json:"file"`
+	URL  string `json:"url"`
+}
+
+func newJob(orchestrator *agent.Orchestrator, conv *conversation.Conversation) *Job {
+	return &Job{
+		id:           newJobID(),
+		agents:       orchestrator.Agents(),
+		orchestrator: orchestrator,
+		conv:         conv,
+		status:       StatusQueued,
+		created:      time.Now().UTC(),
+		results:      make(map[string]agent.Result),
+		events:       []Event{{Type: EventStatus, Status: StatusQueued}},
+		changed:      make(chan struct{}),
+	}
+}
+
+// newJobID returns a random 128-bit hex identifier.
+func newJobID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// setStatus updates the job status and publishes a status event.
+func (j *Job) setStatus(status, errMsg string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.status = status
+	j.err = errMsg
+	switch status {
+	case StatusRunning:
+		j.started = time.Now().UTC()
+	case StatusCompleted, StatusFailed, StatusCanceled:
+		j.finished = time.Now().UTC()
+	}
+	j.publish(Event{Type: EventStatus, Status: status})
+}
+
+// addResult records an agent result and publishes a result event.
+func (j *Job) addResult(result agent.Result) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.results[result.AgentName] = result
+	out := j.outputView(result)
+	j.publish(Event{Type: EventResult, Output: &out})
+}
+
+// publish appends an event and wakes up stream readers. j.mu must be held.
+func (j *Job) publish(e Event) {
+	j.events = append(j.events, e)
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// eventsSince returns the events after index from, a channel that is closed
+// when more events arrive, and whether the job has finished.
+func (j *Job) eventsSince(from int) ([]Event, <-chan struct{}, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var events []Event
+	if from < len(j.events) {
+		events = append(events, j.events[from:]...)
+	}
+	return events, j.changed, j.done()
+}
+
+// done reports whether the job has reached a final status. j.mu must be held.
+func (j *Job) done() bool {
+	switch j.status {
+	case StatusCompleted, StatusFailed, StatusCanceled:
+		return true
+	}
+	return false
+}
+
+// output returns the generated content for an output file.
+func (j *Job) output(file string) (string, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, result := range j.results {
+		if result.Error != nil {
+			continue
+		}
+		if result.OutputFile == file {
+			return result.Content, true
+		}
+		for _, artifact := range result.Artifacts {
+			if artifact.File == file {
+				return artifact.Content, true
+			}
+		}
+	}
+	return "", false
+}
+
+// view returns a snapshot of the job for JSON responses.
+func (j *Job) view() JobView {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	v := JobView{
+		ID:        j.id,
+		Status:    j.status,
+		Agents:    j.agents,
+		Error:     j.err,
+		CreatedAt: j.created.Format(time.RFC3339),
+		Outputs:   []OutputView{},
+	}
+	if !j.started.IsZero() {
+		v.StartedAt = j.started.Format(time.RFC3339)
+	}
+	if !j.finished.IsZero() {
+		v.FinishedAt = j.finished.Format(time.RFC3339)
+	}
+	for _, name := range j.agents {
+		if result, ok := j.results[name]; ok {
+			v.Outputs = append(v.Outputs, j.outputView(result))
+		}
+	}
+	return v
+}
+
+// outputView converts an agent result into its JSON form. j.mu must be held.
+func (j *Job) outputView(result agent.Result) OutputView {
+	out := OutputView{Agent: result.AgentName}
+	if result.Error != nil {
+		out.Error = result.Error.Error()
+		return out
+	}
+	out.File = result.OutputFile
+	out.URL = j.outputURL(result.OutputFile)
+	for _, artifact := range result.Artifacts {
+		out.Artifacts = append(out.Artifacts, ArtifactView{File: artifact.File, URL: j.outputURL(artifact.File)})
+	}
+	out.Diagnostics = result.Diagnostics
+	return out
+}
+
+func (j *Job) outputURL(file string) string {
+	return "/jobs/" + j.id + "/outputs/" + file
+}
@@ -0,0 +1,451 @@
+// Package server exposes the content team as a JSON HTTP API backed by a
+// bounded job queue.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/agent-team-content/internal/agent"
+	"github.com/agentplexus/agent-team-content/internal/conversation"
+	"github.com/agentplexus/agent-team-content/internal/llm"
+	"github.com/agentplexus/agent-team-content/internal/theme"
+)
+
+// Config holds configuration for the HTTP server.
+type Config struct {
+	Addr            string        // Listen address
+	Workers         int           // Jobs processed concurrently
+	QueueSize       int           // Maximum jobs waiting to run
+	MaxBodyBytes    int64         // Maximum request body size
+	JobRetention    time.Duration // How long finished jobs are kept
+	ShutdownTimeout time.Duration // Grace period for running jobs on shutdown
+	Options         agent.Options // Default agent options
+}
+
+// DefaultConfig returns a default server configuration.
+func DefaultConfig() Config {
+	return Config{
+		Addr:            "127.0.0.1:8080",
+		Workers:         2,
+		QueueSize:       16,
+		MaxBodyBytes:    1 << 20,
+		JobRetention:    time.Hour,
+		ShutdownTimeout: 30 * time.Second,
+	}
+}
+
+// Server runs content generation jobs submitted over HTTP.
+type Server struct {
+	client *llm.Client
+	config Config
+
+	mu       sync.Mutex
+	jobs     map[string]*Job
+	queue    chan *Job
+	stopping bool
+
+	quit chan struct{}
+}
+
+// New creates a server that generates content with the given client.
+func New(client *llm.Client, cfg Config) *Server {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize < 1 {
+		cfg.QueueSize = 1
+	}
+	return &Server{
+		client: client,
+		config: cfg,
+		jobs:   make(map[string]*Job),
+		queue:  make(chan *Job, cfg.QueueSize),
+		quit:   make(chan struct{}),
+	}
+}
+
+// Handler returns the HTTP handler for the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /agents", s.handleAgents)
+	mux.HandleFunc("POST /jobs", s.handleSubmit)
+	mux.HandleFunc("GET /jobs/{id}", s.handleStatus)
+	mux.HandleFunc("GET /jobs/{id}/events", s.handleEvents)
+	mux.HandleFunc("GET /jobs/{id}/outputs/{file}", s.handleOutput)
+	return mux
+}
+
+// Run serves the API until ctx is canceled, then stops accepting requests,
+// cancels queued jobs and waits up to ShutdownTimeout for running jobs.
+func (s *Server) Run(ctx context.Context) error {
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	var workers sync.WaitGroup
+	for i := 0; i < s.config.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			s.work(jobCtx)
+		}()
+	}
+
+	srv := &http.Server{
+		Addr:              s.config.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	srv.RegisterOnShutdown(func() { close(s.quit) })
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		s.stop()
+		cancelJobs()
+		workers.Wait()
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+
+	err := srv.Shutdown(shutdownCtx)
+	s.stop()
+
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		cancelJobs()
+		<-done
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return err
+}
+
+// stop prevents new submissions and closes the queue so workers exit once
+// it is drained.
+func (s *Server) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.stopping {
+		s.stopping = true
+		close(s.queue)
+	}
+}
+
+// work processes queued jobs until the queue is closed. Jobs dequeued after
+// shutdown has begun are canceled instead of run.
+func (s *Server) work(ctx context.Context) {
+	for job := range s.queue {
+		s.mu.Lock()
+		stopping := s.stopping
+		s.mu.Unlock()
+		if stopping {
+			job.setStatus(StatusCanceled, "server shutting down")
+			continue
+		}
+
+		job.setStatus(StatusRunning, "")
+		results := job.orchestrator.GenerateWithProgress(ctx, job.conv, job.addResult)
+
+		var failed int
+		for _, result := range results {
+			if result.Error != nil {
+				failed++
+			}
+		}
+		switch {
+		case ctx.Err() != nil:
+			job.setStatus(StatusCanceled, "server shutting down")
+		case failed > 0:
+			job.setStatus(StatusFailed, fmt.Sprintf("%d agent(s) failed", failed))
+		default:
+			job.setStatus(StatusCompleted, "")
+		}
+	}
+}
+
+// JobRequest is the body of a job submission.
+type JobRequest struct {
+	// Conversation is a structured conversation.
+	Conversation *conversation.Conversation `json:"conversation,omitempty"`
+	// Content is a raw conversation in JSON or Markdown, used when
+	// Conversation is not set.
+	Content string `json:"content,omitempty"`
+	// Agents selects agents to run (default: all).
+	Agents []string `json:"agents,omitempty"`
+	// Options overrides agent options.
+	Options *JobOptions `json:"options,omitempty"`
+}
+
+// JobOptions holds per-job agent options.
+type JobOptions struct {
+	MarpTheme   string `json:"marp_theme,omitempty"`
+	RevealTheme string `json:"revealjs_theme,omitempty"`
+}
+
+// validate rejects themes that are not registered theme names. Clients may
+// only choose registered themes, never files on the server.
+func (o *JobOptions) validate(themesDir string) error {
+	if o.MarpTheme == "" && o.RevealTheme == "" {
+		return nil
+	}
+	registry, err := theme.Discover(themesDir)
+	if err != nil {
+		return err
+	}
+	if o.MarpTheme != "" {
+		if _, err := registry.ResolveName(theme.TargetMarp, o.MarpTheme); err != nil {
+			return err
+		}
+	}
+	if o.RevealTheme != "" {
+		if _, err := registry.ResolveName(theme.TargetReveal, o.RevealTheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req JobRequest
+	body := http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	conv := req.Conversation
+	if conv == nil {
+		if req.Content == "" {
+			writeError(w, http.StatusBadRequest, "conversation or content is required")
+			return
+		}
+		var err error
+		conv, err = conversation.Parse([]byte(req.Content))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("failed to parse conversation: %v", err))
+			return
+		}
+	}
+	if len(conv.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "conversation has no messages")
+		return
+	}
+
+	opts := s.config.Options
+	if req.Options != nil {
+		if err := req.Options.validate(opts.ThemesDir); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if req.Options.MarpTheme != "" {
+			opts.MarpTheme = req.Options.MarpTheme
+		}
+		if req.Options.RevealTheme != "" {
+			opts.RevealTheme = req.Options.RevealTheme
+		}
+	}
+
+	agents := req.Agents
+	if len(agents) == 0 {
+		agents = agent.ListAgents()
+	}
+	orchestrator, err := agent.NewOrchestratorWithAgents(s.client, agents, opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job := newJob(orchestrator, conv)
+	if err := s.enqueue(job); err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+job.id)
+	writeJSON(w, http.StatusAccepted, job.view())
+}
+
+// enqueue registers a job and adds it to the queue without blocking.
+func (s *Server) enqueue(job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopping {
+		return errors.New("server is shutting down")
+	}
+
+	s.evictLocked()
+
+	select {
+	case s.queue <- job:
+		s.jobs[job.id] = job
+		return nil
+	default:
+		return errors.New("job queue is full")
+	}
+}
+
+// evictLocked removes finished jobs older than the retention period. s.mu
+// must be held.
+func (s *Server) evictLocked() {
+	cutoff := time.Now().Add(-s.config.JobRetention)
+	for id, job := range s.jobs {
+		job.mu.Lock()
+		expired := job.done() && job.finished.Before(cutoff)
+		job.mu.Unlock()
+		if expired {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *Server) job(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.job(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, job.view())
+}
+
+// handleEvents streams a job's progress as Server-Sent Events. Events that
+// happened before the client connected are replayed first; the stream ends
+// once the job has finished.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.job(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	next := 0
+	for {
+		events, changed, done := job.eventsSince(next)
+		for _, e := range events {
+			data, _ := json.Marshal(e)
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+				return
+			}
+		}
+		next += len(events)
+		flusher.Flush()
+
+		if done {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+func (s *Server) handleOutput(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.job(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+
+	content, ok := job.output(r.PathValue("file"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "output not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(r.PathValue("file")))
+	_, _ = io.WriteString(w, content)
+}
+
+func (s *Server) handleAgents(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]string{"agents": agent.ListAgents()})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	stopping := s.stopping
+	queued := len(s.queue)
+	s.mu.Unlock()
+
+	status := "ok"
+	code := http.StatusOK
+	if stopping {
+		status = "shutting down"
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, map[string]any{"status": status, "queued": queued})
+}
+
+// contentType returns the MIME type for an output file name.
+func contentType(file string) string {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".md":
+		return "text/markdown; charset=utf-8"
+	case ".json":
+		return "application/json"
+	case ".pptx":
+		return "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	}
+	if t := mime.TypeByExtension(path.Ext(file)); t != "" {
+		return t
+	}
+	return "text/plain; charset=utf-8"
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]string{"error": msg})
+}
@@ -0,0 +1,259 @@
+// Package theme discovers and validates presentation theme stylesheets.
+//
+// A theme is a CSS file whose header comment declares its metadata:
+//
+//	/*
+//	 * @theme brand
+//	 * @description Company colors and fonts
+//	 * @target marp
+//	 */
+//
+// @theme is required. @target is marp or revealjs; without it the target
+// is taken from a marp- or revealjs- file name prefix, and a theme with
+// neither may be used for either format.
+package theme
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Targets a theme can be written for.
+const (
+	TargetMarp   = "marp"
+	TargetReveal = "revealjs"
+)
+
+// Theme is a presentation theme.
+type Theme struct {
+	Name        string
+	Description string
+	Target      string // TargetMarp, TargetReveal, or "" for either
+	// Path is the theme's CSS file, or "" for built-in themes.
+	Path string
+	// CSS is the stylesheet. It is empty for upstream themes that the
+	// renderer does not bundle.
+	CSS string
+}
+
+// Builtin reports whether the theme ships with the tool rather than being
+// loaded from a file.
+func (t *Theme) Builtin() bool {
+	return t.Path == ""
+}
+
+//go:embed builtin/*.css
+var builtinFS embed.FS
+
+// upstreamThemes are themes provided by reveal.js whose stylesheets are
+// not bundled. Decks may use them, but they cannot be rendered here.
+var upstreamThemes = []string{"beige", "blood", "dracula", "league", "moon", "night", "serif", "simple", "sky", "solarized"}
+
+// metaPattern matches a "@key value" line in a comment.
+var metaPattern = regexp.MustCompile(`^\s*\*?\s*@([A-Za-z][\w-]*)\s+(.*?)\s*(?:\*/)?\s*$`)
+
+// commentPattern matches a CSS comment.
+var commentPattern = regexp.MustCompile(`(?s)/\*(.*?)\*/`)
+
+// namePattern matches a valid theme name.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][\w-]*$`)
+
+// Parse reads a theme's metadata from the comments in its CSS. file is
+// used to infer the target and in error messages. It returns an error if
+// the CSS does not declare a valid @theme or names an unknown @target.
+func Parse(file, css string) (*Theme, error) {
+	t := &Theme{Path: file, CSS: css}
+
+	meta := map[string]string{}
+	for _, comment := range commentPattern.FindAllStringSubmatch(css, -1) {
+		for _, line := range strings.Split(comment[1], "\n") {
+			if m := metaPattern.FindStringSubmatch(line); m != nil {
+				if _, seen := meta[m[1]]; !seen {
+					meta[m[1]] = m[2]
+				}
+			}
+		}
+	}
+
+	t.Name = meta["theme"]
+	if t.Name == "" {
+		return nil, fmt.Errorf("%s: missing @theme declaration", file)
+	}
+	if !namePattern.MatchString(t.Name) {
+		return nil, fmt.Errorf("%s: invalid theme name %q", file, t.Name)
+	}
+	t.Description = meta["description"]
+
+	switch t.Target = meta["target"]; t.Target {
+	case TargetMarp, TargetReveal:
+	case "":
+		base := filepath.Base(file)
+		for _, target := range []string{TargetMarp, TargetReveal} {
+			if strings.HasPrefix(base, target+"-") {
+				t.Target = target
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s: unknown @target %q (expected %s or %s)", file, t.Target, TargetMarp, TargetReveal)
+	}
+
+	if opening, closing := strings.Count(css, "{"), strings.Count(css, "}"); opening != closing {
+		return nil, fmt.Errorf("%s: unbalanced braces (%d { and %d })", file, opening, closing)
+	}
+	return t, nil
+}
+
+// Load reads and parses a theme CSS file.
+func Load(path string) (*Theme, error) {
+	css, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme: %w", err)
+	}
+	return Parse(path, string(css))
+}
+
+// Registry is the set of built-in themes and themes found in a directory.
+type Registry struct {
+	themes []*Theme
+	// Invalid holds an error for each CSS file in the directory that is not
+	// a valid theme.
+	Invalid []error
+}
+
+// Discover returns a registry of the built-in themes and the *.css files in
+// dir. A missing dir is not an error; invalid files are recorded in
+// Invalid rather than failing discovery.
+func Discover(dir string) (*Registry, error) {
+	r := &Registry{}
+
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		css, err := builtinFS.ReadFile("builtin/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		t, err := Parse(entry.Name(), string(css))
+		if err != nil {
+			return nil, fmt.Errorf("built-in theme %w", err)
+		}
+		t.Path = ""
+		r.themes = append(r.themes, t)
+	}
+	for _, name := range upstreamThemes {
+		r.themes = append(r.themes, &Theme{
+			Name:        name,
+			Description: "reveal.js theme (stylesheet not bundled)",
+			Target:      TargetReveal,
+		})
+	}
+
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.css"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			t, err := Load(file)
+			if err != nil {
+				r.Invalid = append(r.Invalid, err)
+				continue
+			}
+			r.themes = append(r.themes, t)
+		}
+	}
+
+	sort.SliceStable(r.themes, func(i, j int) bool {
+		if r.themes[i].Target != r.themes[j].Target {
+			return r.themes[i].Target < r.themes[j].Target
+		}
+		return r.themes[i].Name < r.themes[j].Name
+	})
+	return r, nil
+}
+
+// Themes returns every valid theme, sorted by target and name.
+func (r *Registry) Themes() []*Theme {
+	return r.themes
+}
+
+// Find returns the theme with the given name for target. Themes loaded
+// from the directory take precedence over built-in themes of the same name.
+func (r *Registry) Find(target, name string) (*Theme, error) {
+	var found *Theme
+	for _, t := range r.themes {
+		if t.Name != name || (t.Target != "" && t.Target != target) {
+			continue
+		}
+		if found == nil || found.Builtin() {
+			found = t
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("unknown %s theme %q (available: %s)", target, name, strings.Join(r.names(target), ", "))
+	}
+	return found, nil
+}
+
+// Resolve returns the theme named by value for target: a registered theme
+// if value is the name of one, otherwise a CSS file if value names an
+// existing file or ends in .css. It reads any file value names, so it is
+// only for trusted values such as command-line flags; use ResolveName for
+// values from clients.
+func (r *Registry) Resolve(target, value string) (*Theme, error) {
+	if namePattern.MatchString(value) {
+		if t, err := r.Find(target, value); err == nil {
+			return t, nil
+		}
+	}
+	if !isFile(value) {
+		return r.Find(target, value)
+	}
+	t, err := Load(value)
+	if err != nil {
+		return nil, err
+	}
+	if t.Target != "" && t.Target != target {
+		return nil, fmt.Errorf("%s: theme %q is for %s, not %s", value, t.Name, t.Target, target)
+	}
+	return t, nil
+}
+
+// ResolveName returns the registered theme named name for target. Unlike
+// Resolve it never reads a file the caller names, and its errors do not
+// depend on which files exist, so it is safe for values from clients.
+func (r *Registry) ResolveName(target, name string) (*Theme, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid %s theme %q: expected a theme name, not a file", target, name)
+	}
+	return r.Find(target, name)
+}
+
+// names returns the names of the themes usable for target.
+func (r *Registry) names(target string) []string {
+	var names []string
+	for _, t := range r.themes {
+		if (t.Target == "" || t.Target == target) && !slices.Contains(names, t.Name) {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// isFile reports whether value refers to a CSS file rather than a theme
+// name.
+func isFile(value string) bool {
+	if strings.EqualFold(filepath.Ext(value), ".css") {
+		return true
+	}
+	info, err := os.Stat(value)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,206 @@
+package thread
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// twitterURLLength is the length X counts for every URL, regardless of its
+// actual length, because links are wrapped by t.co.
+const twitterURLLength = 23
+
+// mastodonURLLength is the length Mastodon counts for every URL.
+const mastodonURLLength = 23
+
+// urlPattern matches links the way X's link detection does for the common
+// cases: explicit http(s) URLs and www. hosts, without trailing punctuation.
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]*[^\s<>".,;:!?)\]'"]`)
+
+// twitterLightRanges are the code point ranges X weighs as one character;
+// everything else (CJK, most symbols) weighs two.
+var twitterLightRanges = [][2]rune{
+	{0x0000, 0x10FF},
+	{0x2000, 0x200D},
+	{0x2010, 0x201F},
+	{0x2032, 0x2037},
+}
+
+// TwitterLength returns the weighted length of text under X's counting rules:
+// URLs count as 23, each emoji sequence counts as 2, code points in the
+// Latin and general punctuation ranges count as 1, and all others count as 2.
+func TwitterLength(text string) int {
+	return lengthWithURLs(text, twitterURLLength, weighRunes)
+}
+
+// BlueskyLength returns the length of text under Bluesky's rules: the
+// number of graphemes, with URLs counted in full. Graphemes are
+// approximated as a base character with its combining marks, or a whole
+// emoji sequence.
+func BlueskyLength(text string) int {
+	count := 0
+	for i := 0; i < len(text); count++ {
+		if n := emojiSequenceLen(text[i:]); n > 0 {
+			i += n
+		} else {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+		}
+		for i < len(text) {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			if !isGraphemeExtend(r) {
+				break
+			}
+			i += size
+		}
+	}
+	return count
+}
+
+// remoteMentionPattern matches a mention of an account on another instance,
+// e.g. @alice@example.social.
+var remoteMentionPattern = regexp.MustCompile(`(^|[^\w/])(@\w+)@[\w-]+(?:\.[\w-]+)+`)
+
+// MastodonLength returns the length of text under Mastodon's rules: URLs
+// count as 23, mentions of remote accounts count only their @username part,
+// and everything else counts one per code point.
+func MastodonLength(text string) int {
+	text = remoteMentionPattern.ReplaceAllString(text, "$1$2")
+	return lengthWithURLs(text, mastodonURLLength, utf8.RuneCountInString)
+}
+
+// lengthWithURLs counts every URL in text as urlLength and weighs the text
+// between them with weigh.
+func lengthWithURLs(text string, urlLength int, weigh func(string) int) int {
+	length := 0
+	rest := text
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		length += weigh(text[len(text)-len(rest) : loc[0]])
+		length += urlLength
+		rest = text[loc[1]:]
+	}
+	return length + weigh(rest)
+}
+
+// weighRunes weighs text without URLs.
+func weighRunes(text string) int {
+	weight := 0
+	for i := 0; i < len(text); {
+		if n := emojiSequenceLen(text[i:]); n > 0 {
+			weight += 2
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		if isTwitterLight(r) {
+			weight++
+		} else {
+			weight += 2
+		}
+	}
+	return weight
+}
+
+func isTwitterLight(r rune) bool {
+	for _, rg := range twitterLightRanges {
+		if r >= rg[0] && r <= rg[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// emojiSequenceLen returns the byte length of the emoji sequence at the
+// start of s (including modifiers, variation selectors, keycaps, tags and
+// ZWJ-joined emoji), or 0 if s does not start with an emoji.
+func emojiSequenceLen(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	if !isEmojiBase(r) {
+		// Keycap sequences: digit, #, or * followed by VS16 and U+20E3.
+		if (r >= '0' && r <= '9') || r == '#' || r == '*' {
+			n := size
+			if r2, s2 := utf8.DecodeRuneInString(s[n:]); r2 == 0xFE0F {
+				n += s2
+			}
+			if r3, s3 := utf8.DecodeRuneInString(s[n:]); r3 == 0x20E3 {
+				return n + s3
+			}
+		}
+		return 0
+	}
+
+	n := size
+	// Symbols that default to text presentation are only emoji with VS16.
+	if isTextDefault(r) {
+		if r2, _ := utf8.DecodeRuneInString(s[n:]); r2 != 0xFE0F {
+			return 0
+		}
+	}
+
+	// A pair of regional indicators forms a single flag.
+	if isRegionalIndicator(r) {
+		if r2, s2 := utf8.DecodeRuneInString(s[n:]); isRegionalIndicator(r2) {
+			return n + s2
+		}
+		return n
+	}
+
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case r == 0xFE0F, r == 0x20E3, isSkinTone(r), r >= 0xE0020 && r <= 0xE007F:
+			n += size
+		case r == 0x200D:
+			next, nsize := utf8.DecodeRuneInString(s[n+size:])
+			if !isEmojiBase(next) {
+				return n
+			}
+			n += size + nsize
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+func isEmojiBase(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r >= 0x2300 && r <= 0x23FF:
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139,
+		r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	}
+	return false
+}
+
+func isTextDefault(r rune) bool {
+	switch r {
+	case 0x00A9, 0x00AE, 0x203C, 0x2049, 0x2122, 0x2139:
+		return true
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// isGraphemeExtend reports whether r continues the preceding grapheme:
+// combining marks, variation selectors, joiners and skin tone modifiers.
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == 0x200C || r == 0x200D || (r >= 0xFE00 && r <= 0xFE0F) || isSkinTone(r)
+}
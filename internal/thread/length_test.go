@@ -0,0 +1,48 @@
+package thread
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTwitterLength(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "hello world", 11},
+		{"latin accents", "café", 4},
+		{"em dash and quotes", "a—“b”", 5},
+		{"ellipsis weighs two", "…", 2},
+		{"cjk", "日本語", 6},
+		{"url", "https://example.com/a/very/long/path/that/is/shortened", 23},
+		{"www url", "www.example.com", 23},
+		{"url with trailing punctuation", "see https://x.com/abc.", 4 + 23 + 1},
+		{"two urls", "https://a.example https://b.example", 23 + 1 + 23},
+		{"emoji", "👍", 2},
+		{"emoji with skin tone", "👍🏽", 2},
+		{"zwj family", "👨‍👩‍👧", 2},
+		{"flag", "🇺🇸", 2},
+		{"keycap", "#️⃣", 2},
+		{"heart with variation selector", "❤️", 2},
+		{"text and emoji", "ship it 🚀", 8 + 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TwitterLength(tt.text); got != tt.want {
+				t.Errorf("TwitterLength(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTwitterLengthLimit(t *testing.T) {
+	if got := TwitterLength(strings.Repeat("a", 280)); got != Twitter.MaxLength {
+		t.Errorf("280 ASCII characters weigh %d, want %d", got, Twitter.MaxLength)
+	}
+	if got := TwitterLength(strings.Repeat("字", 140)); got != Twitter.MaxLength {
+		t.Errorf("140 CJK characters weigh %d, want %d", got, Twitter.MaxLength)
+	}
+}
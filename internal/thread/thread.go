@@ -0,0 +1,420 @@
+// Package thread parses, validates and repairs numbered social media threads
+// against a network's length rules and conventions.
+package thread
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Post is a single numbered post in a thread. Text excludes the "N/" marker.
+type Post struct {
+	Number         int
+	Text           string
+	ContentWarning string // Shown before the text, which is hidden until expanded
+	Media          *Media // Suggested attachment, if any
+}
+
+// Media is a suggested image or video to attach to a post.
+type Media struct {
+	Description string `json:"description"`
+	AltText     string `json:"alt_text"`
+}
+
+// Network describes a platform's post length rules and conventions.
+type Network struct {
+	Name      string
+	MaxLength int
+	Length    func(string) int
+
+	// Handle matches a valid @mention without the @; mentions that do not
+	// match are reported with HandleFormat as the expected form. Nil
+	// disables the check.
+	Handle       *regexp.Regexp
+	HandleFormat string
+
+	MaxHashtagLength int // Longest hashtag the network links, without # (0 = unlimited)
+}
+
+// Twitter is X's 280 weighted-character limit.
+var Twitter = Network{Name: "twitter", MaxLength: 280, Length: TwitterLength}
+
+// Bluesky is Bluesky's 300-grapheme limit. Handles are domain names, and
+// hashtags are linked up to 64 graphemes.
+var Bluesky = Network{
+	Name:             "bluesky",
+	MaxLength:        300,
+	Length:           BlueskyLength,
+	Handle:           regexp.MustCompile(`^[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+$`),
+	HandleFormat:     "@name.bsky.social",
+	MaxHashtagLength: 64,
+}
+
+// MastodonMaxLength is the post length limit of a default Mastodon instance.
+const MastodonMaxLength = 500
+
+// Mastodon returns the rules of a Mastodon instance whose posts are limited
+// to maxLength characters (0 = MastodonMaxLength). Content warnings count
+// toward the limit, and mentions need the account's instance to resolve
+// from other servers.
+func Mastodon(maxLength int) Network {
+	if maxLength <= 0 {
+		maxLength = MastodonMaxLength
+	}
+	return Network{
+		Name:         "mastodon",
+		MaxLength:    maxLength,
+		Length:       MastodonLength,
+		Handle:       regexp.MustCompile(`^\w+@[\w-]+(?:\.[\w-]+)+$`),
+		HandleFormat: "@user@instance.social",
+	}
+}
+
+// Issue kinds reported by Check.
+const (
+	IssueTooLong   = "too_long"
+	IssueNumbering = "numbering"
+	IssueEmpty     = "empty"
+	IssueHandle    = "handle"
+	IssueHashtag   = "hashtag"
+)
+
+// Issue is a problem found in a thread.
+type Issue struct {
+	Post    int // 1-based position in the thread
+	Kind    string
+	Message string
+}
+
+// markerPattern matches the "N/" or "N/M" marker that starts a post,
+// optionally wrapped in Markdown bold.
+var markerPattern = regexp.MustCompile(`^\s*(?:\*\*)?(\d{1,3})\s*/\s*(?:\d{1,3})?(?:\*\*)?(?:\s+|$)(.*)$`)
+
+// Parse splits a numbered thread into posts. Any text before the first
+// marker is returned as the preamble.
+func Parse(text string) ([]Post, string) {
+	var (
+		posts    []Post
+		preamble []string
+		current  *Post
+		lines    []string
+	)
+
+	flush := func() {
+		if current != nil {
+			current.Text = cleanText(lines)
+			posts = append(posts, *current)
+		}
+		lines = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if m := markerPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			n, _ := strconv.Atoi(m[1])
+			current = &Post{Number: n}
+			lines = append(lines, m[2])
+			continue
+		}
+		if current == nil {
+			preamble = append(preamble, line)
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return posts, strings.TrimSpace(strings.Join(preamble, "\n"))
+}
+
+// cleanText joins a post's lines, dropping surrounding blank lines and
+// horizontal rules used as separators.
+func cleanText(lines []string) string {
+	var kept []string
+	for _, line := range lines {
+		if t := strings.TrimSpace(line); t == "---" || t == "***" {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// StripMarker removes a leading "N/" marker from text.
+func StripMarker(text string) string {
+	first, rest, _ := strings.Cut(text, "\n")
+	if m := markerPattern.FindStringSubmatch(first); m != nil {
+		first = m[2]
+	}
+	if rest == "" {
+		return strings.TrimSpace(first)
+	}
+	return strings.TrimSpace(first + "\n" + rest)
+}
+
+// hashtagPattern matches a # at the start of a word followed by a tag that
+// contains at least one letter or underscore, as #123 is not a hashtag.
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\w&])#(\w*[\p{L}_]\w*)`)
+
+// Hashtags returns the hashtags in text, without the leading #.
+func Hashtags(text string) []string {
+	var tags []string
+	for _, m := range hashtagPattern.FindAllStringSubmatch(text, -1) {
+		tags = append(tags, m[1])
+	}
+	return tags
+}
+
+// mentionPattern matches an @mention at the start of a word, including
+// dotted and user@instance forms. Email addresses are not matched.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@/.])@([\w-]+(?:[.@][\w-]+)*)`)
+
+// Mentions returns the @mentions in text, without the leading @.
+func Mentions(text string) []string {
+	var mentions []string
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		mentions = append(mentions, m[1])
+	}
+	return mentions
+}
+
+// Render formats posts as a numbered thread separated by blank lines.
+func Render(posts []Post) string {
+	parts := make([]string, len(posts))
+	for i, p := range posts {
+		parts[i] = Format(p)
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// Format returns a post as published, prefixed with its "N/" marker.
+func Format(p Post) string {
+	return fmt.Sprintf("%d/ %s", p.Number, p.Text)
+}
+
+// Renumber numbers posts sequentially from 1.
+func Renumber(posts []Post) []Post {
+	out := make([]Post, len(posts))
+	for i, p := range posts {
+		out[i] = Post{Number: i + 1, Text: p.Text, ContentWarning: p.ContentWarning, Media: p.Media}
+	}
+	return out
+}
+
+// PostLength returns the length of a post as published, including its marker
+// and content warning.
+func (n Network) PostLength(p Post) int {
+	return n.Length(Format(p)) + n.Length(p.ContentWarning)
+}
+
+// Check reports posts that exceed the length limit, are empty, or break the
+// 1, 2, 3... numbering sequence, and mentions and hashtags that break the
+// network's conventions.
+func (n Network) Check(posts []Post) []Issue {
+	var issues []Issue
+	for i, p := range posts {
+		if p.Number != i+1 {
+			issues = append(issues, Issue{
+				Post:    i + 1,
+				Kind:    IssueNumbering,
+				Message: fmt.Sprintf("numbered %d/, expected %d/", p.Number, i+1),
+			})
+		}
+		if p.Text == "" {
+			issues = append(issues, Issue{Post: i + 1, Kind: IssueEmpty, Message: "post is empty"})
+			continue
+		}
+		if length := n.PostLength(p); length > n.MaxLength {
+			issues = append(issues, Issue{
+				Post:    i + 1,
+				Kind:    IssueTooLong,
+				Message: fmt.Sprintf("%d characters, limit is %d", length, n.MaxLength),
+			})
+		}
+		issues = append(issues, n.checkConventions(i+1, p.Text)...)
+	}
+	return issues
+}
+
+// checkConventions reports mentions that are not valid handles and
+// hashtags that are too long to be linked.
+func (n Network) checkConventions(post int, text string) []Issue {
+	var issues []Issue
+	if n.Handle != nil {
+		for _, mention := range Mentions(text) {
+			if !n.Handle.MatchString(mention) {
+				issues = append(issues, Issue{
+					Post:    post,
+					Kind:    IssueHandle,
+					Message: fmt.Sprintf("@%s is not a %s handle, expected %s", mention, n.Name, n.HandleFormat),
+				})
+			}
+		}
+	}
+	if n.MaxHashtagLength > 0 {
+		for _, tag := range Hashtags(text) {
+			if length := n.Length(tag); length > n.MaxHashtagLength {
+				issues = append(issues, Issue{
+					Post:    post,
+					Kind:    IssueHashtag,
+					Message: fmt.Sprintf("#%s is %d characters, %s links hashtags up to %d", tag, length, n.Name, n.MaxHashtagLength),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// Repair drops empty posts, splits posts that exceed the length limit at
+// sentence or word boundaries, and renumbers the thread. It returns the
+// repaired thread and a description of each change made.
+func (n Network) Repair(posts []Post) ([]Post, []Issue) {
+	var (
+		out     []Post
+		changes []Issue
+	)
+
+	for i, p := range posts {
+		if p.Text == "" {
+			changes = append(changes, Issue{Post: i + 1, Kind: IssueEmpty, Message: "removed empty post"})
+			continue
+		}
+		out = append(out, p)
+	}
+
+	// Splitting can push the thread past 9 or 99 posts, widening every
+	// marker, so repeat until the thread is stable.
+	for pass := 0; pass < 3; pass++ {
+		out = Renumber(out)
+		markerBudget := n.MaxLength - n.Length(fmt.Sprintf("%d/ ", len(out)+9))
+
+		var next []Post
+		split := false
+		for i, p := range out {
+			if n.PostLength(p) <= n.MaxLength {
+				next = append(next, p)
+				continue
+			}
+			parts := n.Split(p.Text, markerBudget-n.Length(p.ContentWarning))
+			if pass == 0 {
+				changes = append(changes, Issue{
+					Post:    i + 1,
+					Kind:    IssueTooLong,
+					Message: fmt.Sprintf("%d characters, split into %d posts", n.PostLength(p), len(parts)),
+				})
+			}
+			for j, part := range parts {
+				post := Post{Text: part, ContentWarning: p.ContentWarning}
+				if j == 0 {
+					post.Media = p.Media
+				}
+				next = append(next, post)
+			}
+			split = true
+		}
+		out = next
+		if !split {
+			break
+		}
+	}
+
+	return Renumber(out), changes
+}
+
+// sentencePattern matches the end of a sentence followed by whitespace.
+var sentencePattern = regexp.MustCompile(`[.!?…]["')\]]*\s+`)
+
+// Split breaks text into chunks whose length is at most budget, preferring
+// paragraph, then sentence, then word boundaries. Paragraphs that share a
+// chunk stay separated by a blank line.
+func (n Network) Split(text string, budget int) []string {
+	if budget <= 0 {
+		return []string{text}
+	}
+
+	var paras []string
+	for _, para := range strings.Split(text, "\n\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			paras = append(paras, para)
+		}
+	}
+
+	return n.pack(paras, budget, "\n\n", func(para string) []string {
+		return n.pack(sentences(para), budget, " ", func(sentence string) []string {
+			return n.pack(strings.Fields(sentence), budget, " ", func(word string) []string {
+				return n.hardSplit(word, budget)
+			})
+		})
+	})
+}
+
+// sentences splits a paragraph into sentences.
+func sentences(para string) []string {
+	var out []string
+	last := 0
+	for _, loc := range sentencePattern.FindAllStringIndex(para, -1) {
+		out = append(out, strings.TrimSpace(para[last:loc[1]]))
+		last = loc[1]
+	}
+	if rest := strings.TrimSpace(para[last:]); rest != "" {
+		out = append(out, rest)
+	}
+	return out
+}
+
+// pack greedily joins units into chunks within budget, breaking units that
+// are too long on their own with breakUnit.
+func (n Network) pack(units []string, budget int, sep string, breakUnit func(string) []string) []string {
+	var (
+		chunks  []string
+		current string
+	)
+	for _, unit := range units {
+		if unit == "" {
+			continue
+		}
+		if n.Length(unit) > budget {
+			if current != "" {
+				chunks = append(chunks, current)
+				current = ""
+			}
+			chunks = append(chunks, breakUnit(unit)...)
+			continue
+		}
+		candidate := unit
+		if current != "" {
+			candidate = current + sep + unit
+		}
+		if n.Length(candidate) > budget {
+			chunks = append(chunks, current)
+			current = unit
+			continue
+		}
+		current = candidate
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// hardSplit cuts text into chunks within budget at rune boundaries.
+func (n Network) hardSplit(text string, budget int) []string {
+	var chunks []string
+	for text != "" {
+		end := 0
+		for end < len(text) {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			if n.Length(text[:end+size]) > budget && end > 0 {
+				break
+			}
+			end += size
+		}
+		chunks = append(chunks, text[:end])
+		text = text[end:]
+	}
+	return chunks
+}
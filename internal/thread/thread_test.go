@@ -0,0 +1,126 @@
+package thread
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		budget int
+		want   []string
+	}{
+		{
+			name:   "fits",
+			text:   "One sentence.",
+			budget: 20,
+			want:   []string{"One sentence."},
+		},
+		{
+			name:   "paragraphs that fit together keep their break",
+			text:   "First.\n\nSecond.",
+			budget: 20,
+			want:   []string{"First.\n\nSecond."},
+		},
+		{
+			name:   "paragraph boundary",
+			text:   "First paragraph.\n\nSecond paragraph.",
+			budget: 20,
+			want:   []string{"First paragraph.", "Second paragraph."},
+		},
+		{
+			name:   "sentence boundary",
+			text:   "One two three. Four five six.",
+			budget: 16,
+			want:   []string{"One two three.", "Four five six."},
+		},
+		{
+			name:   "word boundary",
+			text:   "alpha beta gamma delta",
+			budget: 11,
+			want:   []string{"alpha beta", "gamma delta"},
+		},
+		{
+			name:   "long word",
+			text:   "abcdefghij",
+			budget: 4,
+			want:   []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:   "no budget",
+			text:   "unchanged",
+			budget: 0,
+			want:   []string{"unchanged"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Twitter.Split(tt.text, tt.budget)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%q, %d) = %q, want %q", tt.text, tt.budget, got, tt.want)
+			}
+			for _, chunk := range got {
+				if tt.budget > 0 && Twitter.Length(chunk) > tt.budget {
+					t.Errorf("chunk %q is %d characters, budget is %d", chunk, Twitter.Length(chunk), tt.budget)
+				}
+			}
+		})
+	}
+}
+
+func TestRepair(t *testing.T) {
+	long := strings.Repeat("This sentence is padding. ", 15) + "\n\n" + strings.Repeat("More padding here. ", 10)
+	posts := []Post{
+		{Number: 1, Text: "Intro", Media: &Media{Description: "diagram"}},
+		{Number: 2, Text: ""},
+		{Number: 3, Text: strings.TrimSpace(long), Media: &Media{Description: "chart"}},
+		{Number: 4, Text: "Outro"},
+	}
+
+	got, changes := Twitter.Repair(posts)
+
+	if issues := Twitter.Check(got); len(issues) > 0 {
+		t.Fatalf("repaired thread still has issues: %v", issues)
+	}
+	for i, p := range got {
+		if p.Number != i+1 {
+			t.Errorf("post %d numbered %d", i+1, p.Number)
+		}
+	}
+	if len(got) < 4 {
+		t.Fatalf("got %d posts, want the long post split", len(got))
+	}
+	if got[0].Text != "Intro" || got[len(got)-1].Text != "Outro" {
+		t.Errorf("first and last posts = %q, %q", got[0].Text, got[len(got)-1].Text)
+	}
+	if got[1].Media == nil || got[1].Media.Description != "chart" {
+		t.Errorf("first part of the split post lost its media: %+v", got[1].Media)
+	}
+	for _, p := range got[2 : len(got)-1] {
+		if p.Media != nil {
+			t.Errorf("post %d repeats the split post's media", p.Number)
+		}
+	}
+
+	kinds := map[string]bool{}
+	for _, c := range changes {
+		kinds[c.Kind] = true
+	}
+	if !kinds[IssueEmpty] || !kinds[IssueTooLong] {
+		t.Errorf("changes = %v, want an empty post removed and a long post split", changes)
+	}
+}
+
+func TestRepairKeepsParagraphBreaks(t *testing.T) {
+	text := strings.Repeat("a", 100) + "\n\n" + strings.Repeat("b", 100) + "\n\n" + strings.Repeat("c", 100)
+	got, _ := Twitter.Repair([]Post{{Number: 1, Text: text}})
+	if len(got) != 2 {
+		t.Fatalf("got %d posts, want 2", len(got))
+	}
+	if want := strings.Repeat("a", 100) + "\n\n" + strings.Repeat("b", 100); got[0].Text != want {
+		t.Errorf("first post = %q, want the first two paragraphs separated by a blank line", got[0].Text)
+	}
+}